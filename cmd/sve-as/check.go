@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	sve_as "github.com/fwessels/sve-as"
+)
+
+// checkS re-assembles every `WORD`/`DWORD` comment of a .s file and reports
+// each line whose opcode differs from what Assemble currently produces
+func checkS(fname string, buf []byte) (drift []string) {
+	scanner := bufio.NewScanner(bytes.NewReader(buf))
+	for lineno := 1; scanner.Scan(); lineno++ {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "//") {
+			continue
+		}
+		matches := opcodeComment.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+		have := matches[1] + matches[2]
+		instruction := strings.Split(line, "//")[1]
+		ins := strings.TrimSpace(strings.Split(instruction, "/*")[0])
+
+		var want string
+		if pt, ok := passThrough(ins); ok {
+			drift = append(drift, fmt.Sprintf("%s:%d: $0x%s would be replaced by `%s`: %s", fname, lineno, have, pt, ins))
+			continue
+		} else if opcode, opcode2, err := sve_as.Assemble(ins); err != nil {
+			drift = append(drift, fmt.Sprintf("%s:%d: %v", fname, lineno, err))
+			continue
+		} else if opcode2 == 0 {
+			want = fmt.Sprintf("%08x", opcode)
+		} else {
+			want = fmt.Sprintf("%016x", uint64(opcode2)<<32|uint64(opcode))
+		}
+		if have != want {
+			drift = append(drift, fmt.Sprintf("%s:%d: have $0x%s, want $0x%s: %s", fname, lineno, have, want, ins))
+		}
+	}
+	return
+}
+
+// checkStream checks a stream read from stdin, which must hold a .s file: .asm
+// source has no output file next to it to compare with
+func checkStream(buf []byte) (drift []string, err error) {
+	if !isSStream(buf) {
+		return nil, fmt.Errorf("<stdin>: cannot check .asm source from stdin, pass the .asm file instead")
+	}
+	return checkS("<stdin>", buf), nil
+}
+
+// checkAsm compares the would-be output for an .asm file against the existing
+// .s file and reports each line that differs
+func checkAsm(fname string, buf []byte, outFname string, toPlan9s, keepIncludeComments bool, defines []string) (drift []string) {
+	want, err := asm2s(fname, buf, toPlan9s, keepIncludeComments, defines)
+	if err != nil {
+		return []string{fmt.Sprintf("%s: %v", fname, err)}
+	}
+	have, err := os.ReadFile(outFname)
+	if err != nil {
+		return []string{fmt.Sprintf("%s: %v", fname, err)}
+	}
+
+	haveLines := strings.Split(string(have), "\n")
+	wantLines := strings.Split(want, "\n")
+	for i := 0; i < len(haveLines) || i < len(wantLines); i++ {
+		var h, w string
+		if i < len(haveLines) {
+			h = haveLines[i]
+		}
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if h != w {
+			drift = append(drift, fmt.Sprintf("%s:%d: out of date with %s\n\thave: %s\n\twant: %s", outFname, i+1, fname, h, w))
+		}
+	}
+	return
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// manifest records everything a generated .s file depends on, so that it is
+// regenerated when an included file, a define or sve-as itself changes
+type manifest struct {
+	Tool    string            `json:"tool"`    // version and content hash of sve-as
+	Options []string          `json:"options"` // defines and flags that affect the output
+	Files   map[string]string `json:"files"`   // sha256 of the .asm source and of all its includes, relative to the manifest
+}
+
+// toolHash identifies the running sve-as binary, so that upgrading it invalidates all outputs
+var toolHash = sync.OnceValue(func() string {
+	version := "(devel)"
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		version = info.Main.Version
+	}
+	if exe, err := os.Executable(); err == nil {
+		if sum, err := hashFile(exe); err == nil {
+			return version + " sha256:" + sum
+		}
+	}
+	return version
+})
+
+func hashFile(fname string) (string, error) {
+	f, err := os.Open(fname)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// manifestName returns the name of the (hidden) manifest that sits next to a generated .s file
+func manifestName(outFname string) string {
+	return filepath.Join(filepath.Dir(outFname), "."+filepath.Base(outFname)+".manifest")
+}
+
+// manifestOptions returns the defines and flags that affect the generated output
+func manifestOptions(defines []string, toPlan9s, keepIncludeComments, listing bool) []string {
+	options := make([]string, 0, len(defines)+3)
+	for _, def := range defines {
+		options = append(options, "-D"+def)
+	}
+	options = append(options, fmt.Sprintf("-plan9=%v", toPlan9s), fmt.Sprintf("-keep-include-comments=%v", keepIncludeComments))
+	if listing {
+		options = append(options, "-listing") // so that enabling it regenerates the listings
+	}
+	return options
+}
+
+// asmDependencies returns the .asm file followed by all files it (transitively) includes
+func asmDependencies(fname string, buf []byte, defines []string) ([]string, error) {
+	pp, err := NewPreprocessor(fname, defines)
+	if err != nil {
+		return nil, err
+	}
+	if err := pp.Process(fname, bytes.NewReader(buf), io.Discard); err != nil {
+		return nil, err
+	}
+	return append([]string{fname}, pp.Includes...), nil
+}
+
+// upToDate returns whether the generated .s file exists and none of the
+// dependencies recorded in its manifest have changed
+func upToDate(outFname string, options []string) bool {
+	if _, err := os.Stat(outFname); err != nil {
+		return false
+	}
+	buf, err := os.ReadFile(manifestName(outFname))
+	if err != nil {
+		return false
+	}
+	var m manifest
+	if err := json.Unmarshal(buf, &m); err != nil || m.Tool != toolHash() || !slices.Equal(m.Options, options) {
+		return false
+	}
+	for fname, sum := range m.Files {
+		if !filepath.IsAbs(fname) {
+			fname = filepath.Join(filepath.Dir(outFname), fname)
+		}
+		if cur, err := hashFile(fname); err != nil || cur != sum {
+			return false
+		}
+	}
+	return true
+}
+
+// writeManifest records the dependencies of a generated .s file; their paths are
+// stored relative to the manifest, so that it stays valid when run from another directory
+func writeManifest(outFname string, options, deps []string) error {
+	m := manifest{Tool: toolHash(), Options: options, Files: make(map[string]string, len(deps))}
+	dir, err := filepath.Abs(filepath.Dir(outFname))
+	if err != nil {
+		return err
+	}
+	for _, dep := range deps {
+		sum, err := hashFile(dep)
+		if err != nil {
+			return err
+		}
+		if abs, err := filepath.Abs(dep); err == nil {
+			if rel, err := filepath.Rel(dir, abs); err == nil {
+				dep = filepath.ToSlash(rel)
+			}
+		}
+		m.Files[dep] = sum
+	}
+	buf, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(manifestName(outFname), append(buf, '\n'), 0644)
+}
+
+// writeDepFile writes a Make-style .d file next to the generated file (with its
+// extension replaced), with an empty rule for every include so that make does
+// not fail once it is removed
+func writeDepFile(outFname string, deps []string) error {
+	escape := strings.NewReplacer(" ", `\ `, "#", `\#`, "$", "$$").Replace
+	d := strings.Builder{}
+	d.WriteString(escape(outFname) + ":")
+	for _, dep := range deps {
+		d.WriteString(" " + escape(dep))
+	}
+	d.WriteString("\n")
+	for _, dep := range deps[1:] {
+		d.WriteString("\n" + escape(dep) + ":\n")
+	}
+	return writeFileAtomic(strings.TrimSuffix(outFname, filepath.Ext(outFname))+".d", []byte(d.String()), 0644)
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	sve_as "github.com/fwessels/sve-as"
+)
+
+// unifiedDiff returns a unified diff (with 3 lines of context) that turns a
+// into b, or an empty string if they are identical
+func unifiedDiff(aName, bName, a, b string) string {
+	if a == b {
+		return ""
+	}
+	edits := myersDiff(nil, splitLines(a), splitLines(b))
+	// within a run of changes, list the deletions before the insertions
+	for start := 0; start < len(edits); {
+		end := start
+		for end < len(edits) && edits[end].op != ' ' {
+			end++
+		}
+		slices.SortStableFunc(edits[start:end], func(e, f edit) int { return int(f.op) - int(e.op) })
+		start = end + 1
+	}
+
+	const context = 3
+	out := strings.Builder{}
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", aName, bName)
+	aPos, bPos := 0, 0 // line numbers (0-based) at the start of edits[k]
+	for k := 0; k < len(edits); {
+		if edits[k].op == ' ' {
+			aPos, bPos, k = aPos+1, bPos+1, k+1
+			continue
+		}
+		// start the hunk up to `context` lines before the first change
+		start := max(k-context, 0)
+		for ; k > start && edits[k-1].op == ' '; k-- {
+			aPos, bPos = aPos-1, bPos-1
+		}
+		// extend the hunk until more than 2*context unchanged lines separate it from the next change
+		end, unchanged := k, 0
+		for ; end < len(edits) && unchanged <= 2*context; end++ {
+			if edits[end].op == ' ' {
+				unchanged++
+			} else {
+				unchanged = 0
+			}
+		}
+		end -= max(unchanged-context, 0)
+
+		aLen, bLen := 0, 0
+		for _, e := range edits[k:end] {
+			aLen += sve_as.If(e.op != '+', 1, 0)
+			bLen += sve_as.If(e.op != '-', 1, 0)
+		}
+		fmt.Fprintf(&out, "@@ -%d,%d +%d,%d @@\n", sve_as.If(aLen > 0, aPos+1, aPos), aLen, sve_as.If(bLen > 0, bPos+1, bPos), bLen)
+		for _, e := range edits[k:end] {
+			out.WriteString(string(e.op) + e.line + "\n")
+		}
+		aPos, bPos, k = aPos+aLen, bPos+bLen, end
+	}
+	return out.String()
+}
+
+type edit struct {
+	op   byte // ' ', '-' or '+'
+	line string
+}
+
+// myersDiff appends the edits that turn a into b to edits, using the linear
+// space variant of Myers' O(ND) algorithm: the middle snake of an optimal edit
+// path splits the problem in two halves that are solved recursively
+func myersDiff(edits []edit, a, b []string) []edit {
+	for len(a) > 0 && len(b) > 0 && a[0] == b[0] {
+		edits = append(edits, edit{' ', a[0]})
+		a, b = a[1:], b[1:]
+	}
+	suf := 0
+	for suf < len(a) && suf < len(b) && a[len(a)-1-suf] == b[len(b)-1-suf] {
+		suf++
+	}
+	common := a[len(a)-suf:]
+	a, b = a[:len(a)-suf], b[:len(b)-suf]
+
+	switch {
+	case len(a) == 0:
+		for _, l := range b {
+			edits = append(edits, edit{'+', l})
+		}
+	case len(b) == 0:
+		for _, l := range a {
+			edits = append(edits, edit{'-', l})
+		}
+	default:
+		x, y, u, v := middleSnake(a, b)
+		edits = myersDiff(edits, a[:x], b[:y])
+		for _, l := range a[x:u] {
+			edits = append(edits, edit{' ', l})
+		}
+		edits = myersDiff(edits, a[u:], b[v:])
+	}
+	for _, l := range common {
+		edits = append(edits, edit{' ', l})
+	}
+
+	return edits
+}
+
+// middleSnake returns the middle snake (x, y) → (u, v) of a shortest edit path
+// from a to b, where a and b are non-empty and differ in their first and last lines
+func middleSnake(a, b []string) (x, y, u, v int) {
+	n, m := len(a), len(b)
+	delta, dmax := n-m, (n+m+1)/2
+	off := dmax + 1
+	vf, vb := make([]int, 2*off+1), make([]int, 2*off+1) // furthest x on each diagonal, forward and backward
+	for d := 0; d <= dmax; d++ {
+		for k := -d; k <= d; k += 2 {
+			if k == -d || k != d && vf[off+k-1] < vf[off+k+1] {
+				x = vf[off+k+1]
+			} else {
+				x = vf[off+k-1] + 1
+			}
+			y = x - k
+			u, v = x, y
+			for u < n && v < m && a[u] == b[v] {
+				u, v = u+1, v+1
+			}
+			vf[off+k] = u
+			if kb := delta - k; delta&1 != 0 && -d < kb && kb < d && u+vb[off+kb] >= n {
+				return x, y, u, v
+			}
+		}
+		// backward, with x and y counted from the ends of a and b
+		for k := -d; k <= d; k += 2 {
+			if k == -d || k != d && vb[off+k-1] < vb[off+k+1] {
+				x = vb[off+k+1]
+			} else {
+				x = vb[off+k-1] + 1
+			}
+			y = x - k
+			u, v = x, y
+			for u < n && v < m && a[n-1-u] == b[m-1-v] {
+				u, v = u+1, v+1
+			}
+			vb[off+k] = u
+			if kf := delta - k; delta&1 == 0 && -d <= kf && kf <= d && u+vf[off+kf] >= n {
+				return n - u, m - v, n - x, m - y
+			}
+		}
+	}
+	panic("unreachable")
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// input is a file to process, together with its directory relative to the
+// root of the pattern that matched it (so that -output-path can mirror the tree)
+type input struct {
+	fname string
+	rel   string
+}
+
+func isAsmFile(fname string) bool { return strings.HasSuffix(strings.ToLower(fname), ".asm") }
+func isSFile(fname string) bool   { return strings.HasSuffix(strings.ToLower(fname), ".s") }
+
+// expandPatterns turns the command line arguments into the files to process.
+// Like the go command, a directory stands for the files it contains and a
+// `dir/...` pattern for the files in dir and all its subdirectories (skipping
+// testdata and directories starting with `.` or `_`). Within directories all
+// *.asm files are selected and, with withS, also the *_arm64.s files that
+// contain WORD/DWORD directives (unless generated from one of the *.asm files).
+// Any other argument is taken as a file name, or `-` for stdin.
+func expandPatterns(args []string, withS bool) (inputs []input, err error) {
+	for _, arg := range args {
+		root, recursive := arg, false
+		if arg == "..." || strings.HasSuffix(arg, "/...") {
+			root, recursive = filepath.Clean(strings.TrimSuffix(arg, "...")), true
+		}
+		if st, err := os.Stat(root); arg == "-" || err != nil || !st.IsDir() {
+			if recursive {
+				return nil, fmt.Errorf("%s: no such directory: %s", arg, root)
+			}
+			inputs = append(inputs, input{fname: arg})
+			continue
+		}
+
+		var asms, ss []input
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if path == root {
+					return nil
+				} else if name := d.Name(); !recursive || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			rel, _ := filepath.Rel(root, filepath.Dir(path))
+			if isAsmFile(path) {
+				asms = append(asms, input{fname: path, rel: rel})
+			} else if withS && strings.HasSuffix(strings.ToLower(path), "_arm64.s") {
+				if buf, err := os.ReadFile(path); err == nil && isSStream(buf) {
+					ss = append(ss, input{fname: path, rel: rel})
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, asms...)
+		for _, s := range ss {
+			generated := false
+			for _, asm := range asms {
+				generated = generated || outputName(asm.fname, "", "") == s.fname
+			}
+			if !generated {
+				inputs = append(inputs, s)
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	sve_as "github.com/fwessels/sve-as"
+)
+
+// instructionRecord is the machine-readable description of an instruction assembled by sve-as
+type instructionRecord struct {
+	Input       string   `json:"input"`       // the file that was processed
+	File        string   `json:"file"`        // file the instruction originates from (e.g. an included header)
+	Line        int      `json:"line"`        // line number within file
+	Source      string   `json:"source"`      // source line as written (e.g. a macro invocation)
+	Instruction string   `json:"instruction"` // instruction after macro expansion
+	Opcodes     []string `json:"opcodes"`     // encoded word(s) in memory order
+	Movprfx     bool     `json:"movprfx"`     // whether a movprfx was inserted automatically
+	Feature     string   `json:"feature"`     // architecture feature required, see sve_as.Feature
+}
+
+// jsonRecords returns a JSON object (on a line of its own) for every instruction
+// of an .asm or .s file that is assembled by sve-as
+func jsonRecords(fname string, buf []byte, isAsm bool, defines []string) (string, error) {
+	lines, err := sourceLines(fname, buf, isAsm, defines)
+	if err != nil {
+		return "", err
+	}
+
+	out := strings.Builder{}
+	for _, l := range lines {
+		code, words, _, assembled, err := encodeLine(l.text)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", l.origin, err)
+		} else if !assembled {
+			continue
+		}
+		opcode, _ := strconv.ParseUint(words[len(words)-1], 16, 32)
+		file, line, _ := strings.Cut(l.origin, ":")
+		lineno, _ := strconv.Atoi(line)
+		rec, err := json.Marshal(instructionRecord{
+			Input:       fname,
+			File:        file,
+			Line:        lineno,
+			Source:      strings.TrimSpace(l.source),
+			Instruction: code,
+			Opcodes:     words,
+			Movprfx:     len(words) == 2,
+			Feature:     sve_as.Feature(code, uint32(opcode)),
+		})
+		if err != nil {
+			return "", err
+		}
+		out.Write(append(rec, '\n'))
+	}
+	return out.String(), nil
+}
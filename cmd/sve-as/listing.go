@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	sve_as "github.com/fwessels/sve-as"
+)
+
+// sourceLine is a line of (macro expanded) source together with where it came from
+type sourceLine struct {
+	origin   string // file:line
+	source   string // the line as written in the source file
+	text     string // the line after macro expansion
+	expanded bool   // whether text is (part of) the expansion of a macro invoked by source
+}
+
+// sourceLines returns the lines of an .asm file after preprocessing, or the
+// lines of a .s file, along with their origin
+func sourceLines(fname string, buf []byte, isAsm bool, defines []string) (lines []sourceLine, err error) {
+	if !isAsm {
+		scanner := bufio.NewScanner(bytes.NewReader(buf))
+		for lineno := 1; scanner.Scan(); lineno++ {
+			origin := fmt.Sprintf("%s:%d", filepath.Base(fname), lineno)
+			lines = append(lines, sourceLine{origin: origin, source: scanner.Text(), text: scanner.Text()})
+		}
+		return
+	}
+
+	// preprocess with a `// file:line` marker in front of the expansion of every source line
+	pp, err := NewPreprocessor(fname, defines)
+	if err != nil {
+		return nil, err
+	}
+	pp.KeepLineComments, pp.FullLineCommentPath = true, true
+	preprocessed := bytes.Buffer{}
+	if err := pp.Process(fname, bytes.NewReader(buf), &preprocessed); err != nil {
+		return nil, err
+	}
+	// keyed by full path, as different directories may hold includes with the same name
+	sources := map[string][]string{filepath.Clean(pp.EntryFile): strings.Split(string(buf), "\n")}
+	for _, inc := range pp.Includes {
+		if b, err := os.ReadFile(inc); err == nil {
+			sources[filepath.Clean(inc)] = strings.Split(string(b), "\n")
+		}
+	}
+
+	marker := regexp.MustCompile(`^// (.+):(\d+)$`)
+	origin, source := "", ""
+	scanner := bufio.NewScanner(&preprocessed)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := marker.FindStringSubmatch(line); m != nil {
+			lineno, _ := strconv.Atoi(m[2])
+			origin, source = filepath.Base(m[1])+":"+m[2], ""
+			if src := sources[filepath.Clean(m[1])]; 0 < lineno && lineno <= len(src) {
+				source = src[lineno-1]
+			}
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		expanded := strings.TrimSpace(line) != strings.TrimSpace(source)
+		lines = append(lines, sourceLine{origin: origin, source: source, text: line, expanded: expanded})
+	}
+	return
+}
+
+// encodeLine returns the instruction on a line of source (taken from the
+// comment for the WORD/DWORD directives of a .s file) and the number of bytes
+// of code it emits. For instructions assembled by sve-as, words holds the
+// opcode(s) in memory order and assembled is true; for instructions left to
+// the Go assembler, words holds a placeholder and size is taken to be 4.
+func encodeLine(text string) (code string, words []string, size int, assembled bool, err error) {
+	code, _, _ = strings.Cut(text, "//")
+	code = strings.TrimSpace(code)
+	if opcodeComment.MatchString(text) {
+		code = strings.TrimSpace(strings.Split(strings.SplitN(text, "//", 2)[1], "/*")[0])
+	}
+
+	switch {
+	case code == "" || strings.HasPrefix(code, "#") || strings.HasSuffix(code, ":"):
+		// comment, directive or label
+	case allCaps(strings.Fields(code)[0]):
+		switch mnem := strings.Fields(code)[0]; mnem {
+		case "TEXT", "DATA", "GLOBL", "FUNCDATA", "PCDATA", "NO_LOCAL_POINTERS", "PCALIGN":
+		case "WORD", "DWORD":
+			if len(strings.Fields(code)) > 1 {
+				size, words = sve_as.If(mnem == "WORD", 4, 8), []string{strings.TrimPrefix(strings.Fields(code)[1], "$0x")}
+			}
+		default:
+			size, words = 4, []string{"(go asm)"}
+		}
+	default:
+		if _, ok := passThrough(code); ok {
+			return code, []string{"(go asm)"}, 4, false, nil
+		}
+		if mnem, rt, _, ok := labelLiteral(code); ok {
+			// ADR of the label into R27 followed by the load from there
+			opcode, _, err := sve_as.Assemble(mnem + " " + rt + ", [x27]")
+			return code, []string{"(go asm)", fmt.Sprintf("%08x", opcode)}, 8, false, err
+		}
+		opcode, opcode2, err := sve_as.Assemble(code)
+		if err != nil {
+			return code, nil, 0, false, err
+		} else if opcode2 == 0 {
+			return code, []string{fmt.Sprintf("%08x", opcode)}, 4, true, nil
+		}
+		// the low word (i.e. the movprfx) comes first in memory
+		return code, []string{fmt.Sprintf("%08x", opcode), fmt.Sprintf("%08x", opcode2)}, 8, true, nil
+	}
+	return
+}
+
+// listingName returns the name of the listing file that goes with a generated .s file
+func listingName(outFname string) string {
+	return strings.TrimSuffix(outFname, filepath.Ext(outFname)) + ".lst"
+}
+
+// listing returns the listing for an .asm or .s file, with for every line the
+// byte offset within its TEXT routine, the encoded word(s), the file:line
+// origin and the source text, followed by the expansion of macro invocations.
+// Instructions left to the Go assembler are counted as a single 4-byte
+// instruction, and no function prologue is assumed (as for NOSPLIT routines
+// with a $0 frame).
+func listing(fname string, buf []byte, isAsm bool, defines []string) (string, error) {
+	lines, err := sourceLines(fname, buf, isAsm, defines)
+	if err != nil {
+		return "", err
+	}
+
+	out := strings.Builder{}
+	offset := 0
+	for i, l := range lines {
+		text := l.text
+		if l.expanded {
+			if i == 0 || lines[i-1].origin != l.origin {
+				// list the macro invocation once, followed by its expansion
+				fmt.Fprintf(&out, "%-6s%-19s%-20s %s\n", "", "", l.origin, strings.TrimSpace(l.source))
+			}
+			text = "  => " + strings.TrimSpace(text)
+		}
+
+		code, words, size, _, err := encodeLine(l.text)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", l.origin, err)
+		}
+		if fields := strings.Fields(code); len(fields) > 0 {
+			switch fields[0] {
+			case "TEXT":
+				offset = 0
+			case "PCALIGN":
+				if len(fields) > 1 {
+					if align, err := strconv.ParseInt(strings.TrimPrefix(fields[1], "$"), 0, 32); err == nil && align > 0 {
+						offset = (offset + int(align) - 1) / int(align) * int(align)
+					}
+				}
+			}
+		}
+
+		if size == 0 && !strings.HasSuffix(code, ":") {
+			fmt.Fprintf(&out, "%-6s%-19s%-20s %s\n", "", "", l.origin, text)
+		} else {
+			fmt.Fprintf(&out, "%04x  %-19s%-20s %s\n", offset, strings.Join(words, " "), l.origin, text)
+		}
+		offset += size
+	}
+	return out.String(), nil
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -13,14 +14,17 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
-	"sync"
 	"unicode"
 
 	sve_as "github.com/fwessels/sve-as"
 	"github.com/fwessels/sve-as/internal/preprocessor"
 )
 
-func assemble(buf []byte, hasDWordsMap *map[string]bool) (out string, containsDWordsMap map[string]bool) {
+// opcodeComment matches a `WORD`/`DWORD` directive followed by the instruction it
+// encodes, capturing the opcode of a WORD (1st group) or a DWORD (2nd group)
+var opcodeComment = regexp.MustCompile(`(?:WORD \$0x([0-9a-f]{8})|DWORD \$0x([0-9a-f]{16}))\s*//`)
+
+func assemble(buf []byte, hasDWordsMap *map[string]bool) (out string, containsDWordsMap map[string]bool, err error) {
 	containsDWordsMap = make(map[string]bool)
 
 	assembled := strings.Builder{}
@@ -44,7 +48,7 @@ func assemble(buf []byte, hasDWordsMap *map[string]bool) (out string, containsDW
 
 		if strings.HasPrefix(line, "//") {
 			// Intentionally ignore (skip full line of comments)
-		} else if regexp.MustCompile(`(?:WORD \$0x[0-9a-f]{8}|DWORD \$0x[0-9a-f]{16})\s*//`).MatchString(line) {
+		} else if opcodeComment.MatchString(line) {
 			instruction := strings.Split(line, "//")[1]
 			ins := strings.Split(instruction, "/*")[0]
 			if pt, ok := passThrough(ins); ok {
@@ -52,11 +56,10 @@ func assemble(buf []byte, hasDWordsMap *map[string]bool) (out string, containsDW
 			} else {
 				opcode, opcode2, err := sve_as.Assemble(ins)
 				if err != nil {
-					fmt.Println(err)
 					if msg := gnuAsmError(ins); msg != "" {
-						fmt.Println(msg)
+						err = fmt.Errorf("%w\n%s", err, msg)
 					}
-					os.Exit(1)
+					return "", nil, err
 				}
 
 				if opcode2 == 0 {
@@ -88,6 +91,27 @@ func allCaps(s string) (hasLetter bool) {
 	return
 }
 
+var (
+	labelName    = regexp.MustCompile(`^[A-Za-z_.][A-Za-z0-9_.]*$`)
+	registerName = regexp.MustCompile(`^(?:[a-z][0-9]+|sp|[xw]zr)$`)
+)
+
+// labelLiteral matches a PC-relative load of a label, `ldr <Rt>, <label>` or
+// `ldrsw <Xt>, <label>`. As the Go assembler cannot load from a label, the
+// .s output takes its address into R27 (REGTMP, the scratch register of the Go
+// assembler) with an ADR and loads from there; the other targets resolve the
+// label within the routine instead.
+func labelLiteral(ins string) (mnem, rt, label string, ok bool) {
+	fields := strings.Fields(strings.ReplaceAll(ins, ",", " "))
+	if len(fields) != 3 || (fields[0] != "ldr" && fields[0] != "ldrsw") || !labelName.MatchString(fields[2]) || registerName.MatchString(fields[2]) {
+		return "", "", "", false
+	}
+	if _, _, err := sve_as.Assemble(fields[0] + " " + fields[1] + ", #0"); err != nil {
+		return "", "", "", false
+	}
+	return fields[0], fields[1], fields[2], true
+}
+
 // Check for instructions to pass through and/or translate into plan9s equivalents
 func passThrough(ins string) (string, bool) {
 	reg2Plan9s := func(reg string) string {
@@ -174,7 +198,7 @@ func passThrough(ins string) (string, bool) {
 	return "", false
 }
 
-func NewPreprocessor(fname string) (pp *preprocessor.Preprocessor, err error) {
+func NewPreprocessor(fname string, defines []string) (pp *preprocessor.Preprocessor, err error) {
 	pp = preprocessor.NewPreprocessor()
 	pp.KeepLineComments = false // true for `// textflag.h:10` references
 	if fname != "" {
@@ -192,18 +216,18 @@ func NewPreprocessor(fname string) (pp *preprocessor.Preprocessor, err error) {
 	runtimePath := filepath.Join(goroot, "src", "runtime")
 	pp.IncludeDirs = append(pp.IncludeDirs, runtimePath)
 
-	// // Apply -D defines
-	// for _, def := range D {
-	// 	name, val := asmpp.ParseDefine(def)
-	// 	pp.DefineObject(name, val)
-	// }
+	// Apply -D defines
+	for _, def := range defines {
+		name, val := preprocessor.ParseDefine(def)
+		pp.DefineObject(name, val)
+	}
 	return
 }
 
-func asm2s(fname string, buf []byte, toPlan9s bool, keepIncludeComments bool) (out string, err error) {
+func asm2s(fname string, buf []byte, toPlan9s bool, keepIncludeComments bool, defines []string) (out string, err error) {
 
 	var pp *preprocessor.Preprocessor
-	if pp, err = NewPreprocessor(fname); err != nil {
+	if pp, err = NewPreprocessor(fname, defines); err != nil {
 		return "", err
 	}
 	pp.KeepIncludeComments = keepIncludeComments
@@ -232,14 +256,20 @@ func asm2s(fname string, buf []byte, toPlan9s bool, keepIncludeComments bool) (o
 			// pass along verbatim
 		} else if pt, ok := passThrough(line); ok {
 			line = "    " + pt
+		} else if mnem, rt, label, ok := labelLiteral(line); ok {
+			load := mnem + " " + rt + ", [x27]"
+			opcode, _, err := sve_as.Assemble(load)
+			if err != nil {
+				return "", err
+			}
+			line = fmt.Sprintf("    ADR %s, R27\n    WORD $0x%08x // %s /* %s */", label, opcode, load, strings.TrimSpace(line))
 		} else {
 			opcode, opcode2, err := sve_as.Assemble(line)
 			if err != nil {
-				fmt.Println(err)
 				if msg := gnuAsmError(line); msg != "" {
-					fmt.Println(msg)
+					err = fmt.Errorf("%w\n%s", err, msg)
 				}
-				os.Exit(2)
+				return "", err
 			}
 			inlineComment = strings.TrimSpace(inlineComment)
 			if opcode2 == 0 {
@@ -427,95 +457,256 @@ func translateBackToPlan9s(opcodes string) (string, error) {
 	return plan9s.String(), nil
 }
 
+// process returns the contents of the .s file for an .asm or .s input
+func process(fname string, buf []byte, isAsm, toPlan9s, keepIncludeComments bool, defines []string) (string, error) {
+	if isAsm {
+		return asm2s(fname, buf, toPlan9s, keepIncludeComments, defines)
+	}
+	_, containsDWordsMap, err := assemble(buf, nil)
+	if err != nil {
+		return "", err
+	}
+	processed, _, err := assemble(buf, &containsDWordsMap)
+	return processed, err
+}
+
+// isSStream returns whether a stream (without a file name to go by) holds a .s
+// file, i.e. contains `WORD`/`DWORD` directives to re-assemble, rather than .asm source
+func isSStream(buf []byte) bool {
+	scanner := bufio.NewScanner(bytes.NewReader(buf))
+	for scanner.Scan() {
+		if line := scanner.Text(); !strings.HasPrefix(line, "//") && opcodeComment.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// outputName returns the name of the .s file that is generated for an .asm file,
+// where rel is the directory (relative to -output-path) to mirror the source tree
+func outputName(fname, rel, outputPath string) string {
+	base := filepath.Base(fname)
+	outName := base[:len(base)-len(filepath.Ext(base))] + ".s"
+	if outputPath != "" {
+		return filepath.Join(outputPath, rel, outName)
+	}
+	return filepath.Join(filepath.Dir(fname), outName)
+}
+
 func main() {
+	// `sve-as check` verifies that the generated files are up to date, without writing anything
+	check := len(os.Args) > 1 && os.Args[1] == "check"
+	if check {
+		os.Args = append(os.Args[:1], os.Args[2:]...)
+	}
+
 	plan9 := flag.Bool("plan9", false, "enable plan9 disassembly for asm mode")
 	outputPath := flag.String("output-path", "", "directory for output .s files (asm mode only)")
 	force := flag.Bool("f", false, "force processing even if output is newer than input (asm mode)")
 	keepIncludeComments := flag.Bool("keep-include-comments", false, "keep comment-only lines from included files (asm mode)")
+	diff := flag.Bool("diff", false, "print a unified diff of the changes instead of writing them")
+	depFiles := flag.Bool("deps", false, "write Make-style .d dependency files next to the output .s files (asm mode)")
+	var defines []string
+	listingFiles := flag.Bool("listing", false, "write a .lst listing file with offsets, encodings and source origins next to each output")
+	format := flag.String("format", "text", "output format: text, or json to print a JSON object per assembled instruction instead of writing files")
+	target := flag.String("target", "s", "output target: s to rewrite Go .s files, go for a []uint32 per routine, c for a C header, gnu for GNU .inst lines, or bin for a flat little-endian binary")
+	goPackage := flag.String("go-package", "", "package name for -target=go (default: derived from the output directory)")
+	jobs := flag.Int("j", runtime.GOMAXPROCS(0), "number of files to process in parallel")
+	withS := flag.Bool("with-s", false, "also process *_arm64.s files with WORD/DWORD directives found in directories")
+	flag.Func("D", "define a preprocessor macro as `name[=value]` (asm mode, may be repeated)", func(def string) error {
+		defines = append(defines, def)
+		return nil
+	})
 	flag.Parse()
 
-	args := flag.Args()
-	if len(args) < 1 {
-		fmt.Println("Usage: sve-as [-plan9] [-f] [-output-path <dir>] <filename.s/.asm> [...]")
+	if flag.NArg() < 1 {
+		fmt.Println("Usage: sve-as [check] [-plan9] [-f] [-diff] [-deps] [-listing] [-format text|json] [-target s|go|c|gnu|bin] [-with-s] [-j n] [-D name[=value]] [-output-path <dir>] <filename.s/.asm | dir | dir/... | -> [...]")
+		os.Exit(1)
+	}
+	args, err := expandPatterns(flag.Args(), *withS)
+	if err != nil {
+		fmt.Println(err)
 		os.Exit(1)
 	}
 
-	var wg sync.WaitGroup
-	errs := make(chan error, len(args))
-
-	for _, fname := range args {
-		fname = strings.ToLower(fname)
-		isAsm, isS := strings.HasSuffix(fname, ".asm"), strings.HasSuffix(fname, ".s")
-		if !isAsm && !isS {
-			fmt.Println("Usage: sve-as [-plan9] [-f] [-output-path <dir>] <filename.s/.asm> [...]")
+	if *format != "text" && *format != "json" {
+		fmt.Printf("invalid -format %q, must be text or json\n", *format)
+		os.Exit(1)
+	}
+	switch *target {
+	case "s", "go", "c", "gnu", "bin":
+	default:
+		fmt.Printf("invalid -target %q, must be s, go, c, gnu or bin\n", *target)
+		os.Exit(1)
+	}
+	for _, in := range args {
+		if in.fname != "-" && !isAsmFile(in.fname) && !isSFile(in.fname) {
+			fmt.Println("Usage: sve-as [check] [-plan9] [-f] [-diff] [-deps] [-listing] [-format text|json] [-target s|go|c|gnu|bin] [-with-s] [-j n] [-D name[=value]] [-output-path <dir>] <filename.s/.asm | dir | dir/... | -> [...]")
 			os.Exit(1)
 		}
+	}
 
-		if isAsm {
-			outName := strings.ReplaceAll(filepath.Base(fname), ".asm", ".s")
-			outFname := filepath.Join(filepath.Dir(fname), outName)
-			if *outputPath != "" {
-				outFname = filepath.Join(*outputPath, outName)
-			}
-			if !*force {
-				if srcInfo, err := os.Stat(fname); err == nil {
-					if dstInfo, err := os.Stat(outFname); err == nil {
-						if dstInfo.ModTime().After(srcInfo.ModTime()) {
-							fmt.Printf("Skipping %s (up to date)\n", fname)
-							continue
-						}
-					}
+	if check {
+		failed := runOrdered(len(args), *jobs, os.Stdout, os.Stderr, func(i int, r *result) {
+			fname := args[i].fname
+			if fname == "-" {
+				buf, err := io.ReadAll(os.Stdin)
+				if err != nil {
+					r.err = fmt.Errorf("error reading stdin: %w", err)
+					return
+				}
+				drift, err := checkStream(buf)
+				if err != nil {
+					r.err = err
+					return
+				}
+				for _, d := range drift {
+					fmt.Fprintln(&r.log, d)
+					r.failed = true
 				}
+				return
 			}
-		}
-
-		wg.Add(1)
-		go func(fname string, isAsm, isS bool) {
-			defer wg.Done()
 			buf, err := os.ReadFile(fname)
 			if err != nil {
-				errs <- fmt.Errorf("error reading file %s: %w", fname, err)
+				r.err = fmt.Errorf("error reading file %s: %w", fname, err)
 				return
 			}
-			var processed string
-			if isAsm {
-				outName := strings.ReplaceAll(filepath.Base(fname), ".asm", ".s")
-				outFname := filepath.Join(filepath.Dir(fname), outName)
-				if *outputPath != "" {
-					outFname = filepath.Join(*outputPath, outName)
-				}
-				fmt.Printf("Processing %s → %s\n", fname, outFname)
-				if processed, err = asm2s(fname, buf, *plan9, *keepIncludeComments); err != nil {
-					errs <- fmt.Errorf("%s: %w", fname, err)
-					return
-				}
-				fname = outFname
+			var drift []string
+			if isAsmFile(fname) {
+				drift = checkAsm(fname, buf, outputName(fname, args[i].rel, *outputPath), *plan9, *keepIncludeComments, defines)
+			} else {
+				drift = checkS(fname, buf)
 			}
-			if isS {
-				fmt.Println("Processing", fname)
-				_, containsDWordsMap := assemble(buf, nil)
-				processed, _ = assemble(buf, &containsDWordsMap)
+			for _, d := range drift {
+				fmt.Fprintln(&r.log, d)
+				r.failed = true
 			}
-			if err := os.MkdirAll(filepath.Dir(fname), 0755); err != nil {
-				errs <- fmt.Errorf("error creating directory for %s: %w", fname, err)
+		})
+		os.Exit(sve_as.If(failed, 1, 0))
+	}
+
+	options := manifestOptions(defines, *plan9, *keepIncludeComments, *listingFiles)
+	if *target == "go" {
+		options = append(options, "-go-package="+*goPackage)
+	}
+	// generate returns the contents of the output file for the target
+	generate := func(fname, outFname string, buf []byte, isAsm bool) (string, error) {
+		if *target == "s" {
+			return process(fname, buf, isAsm, *plan9, *keepIncludeComments, defines)
+		}
+		pkg := *goPackage
+		if pkg == "" {
+			dir, _ := filepath.Abs(filepath.Dir(outFname))
+			pkg = identifier(strings.ToLower(filepath.Base(dir)))
+		}
+		return emitTarget(*target, fname, buf, isAsm, defines, pkg)
+	}
+	failed := runOrdered(len(args), *jobs, os.Stdout, os.Stderr, func(i int, r *result) {
+		fname, isAsm := args[i].fname, isAsmFile(args[i].fname)
+		if *format == "json" {
+			// report only, nothing is written
+			var buf []byte
+			var err error
+			if fname == "-" {
+				buf, err = io.ReadAll(os.Stdin)
+				isAsm = !isSStream(buf)
+			} else {
+				buf, err = os.ReadFile(fname)
+			}
+			if err != nil {
+				r.err = fmt.Errorf("error reading %s: %w", fname, err)
 				return
 			}
-			if err := os.WriteFile(fname, []byte(processed), 0644); err != nil {
-				errs <- fmt.Errorf("error writing %s: %w", fname, err)
+			if r.stdout, err = jsonRecords(fname, buf, isAsm, defines); err != nil {
+				r.err = fmt.Errorf("%s: %w", fname, err)
+			}
+			return
+		}
+		if fname == "-" {
+			// read from stdin, write to stdout
+			buf, err := io.ReadAll(os.Stdin)
+			if err != nil {
+				r.err = fmt.Errorf("error reading stdin: %w", err)
 				return
 			}
-		}(fname, isAsm, isS)
-	}
+			processed, err := generate(fname, fname, buf, !isSStream(buf))
+			if err != nil {
+				r.err = fmt.Errorf("<stdin>: %w", err)
+				return
+			}
+			r.stdout = sve_as.If(*diff, unifiedDiff("<stdin>", "<stdin>", string(buf), processed), processed)
+			return
+		}
 
-	wg.Wait()
-	close(errs)
+		sFname := fname
+		if isAsm {
+			sFname = outputName(fname, args[i].rel, *outputPath)
+		}
+		outFname := targetName(sFname, *target)
+		if isAsm {
+			if !*force && upToDate(outFname, options) {
+				if !*diff {
+					fmt.Fprintf(&r.log, "Skipping %s (up to date)\n", fname)
+				}
+				return
+			}
+		}
 
-	exitCode := 0
-	for err := range errs {
-		fmt.Println(err)
-		exitCode = 1
-	}
-	os.Exit(exitCode)
+		buf, err := os.ReadFile(fname)
+		if err != nil {
+			r.err = fmt.Errorf("error reading file %s: %w", fname, err)
+			return
+		}
+		current := buf
+		if isAsm || *target != "s" {
+			if !*diff {
+				fmt.Fprintf(&r.log, "Processing %s → %s\n", fname, outFname)
+			}
+			current, _ = os.ReadFile(outFname) // compare against an empty file when not yet generated
+		} else if !*diff {
+			fmt.Fprintln(&r.log, "Processing", fname)
+		}
+		processed, err := generate(fname, outFname, buf, isAsm)
+		if err != nil {
+			r.err = fmt.Errorf("%s: %w", fname, err)
+			return
+		}
+		if *diff {
+			r.stdout = unifiedDiff(outFname, outFname, string(current), processed)
+			return
+		}
+		if err := os.MkdirAll(filepath.Dir(outFname), 0755); err != nil {
+			r.err = fmt.Errorf("error creating directory for %s: %w", outFname, err)
+			return
+		}
+		if err := writeFileAtomic(outFname, []byte(processed), 0644); err != nil {
+			r.err = fmt.Errorf("error writing %s: %w", outFname, err)
+			return
+		}
+		if *listingFiles {
+			lst, err := listing(fname, sve_as.If(isAsm || *target != "s", buf, []byte(processed)), isAsm, defines)
+			if err == nil {
+				err = writeFileAtomic(listingName(sFname), []byte(lst), 0644)
+			}
+			if err != nil {
+				r.err = fmt.Errorf("error writing listing of %s: %w", outFname, err)
+				return
+			}
+		}
+		if isAsm {
+			deps, err := asmDependencies(fname, buf, defines)
+			if err == nil {
+				err = writeManifest(outFname, options, deps)
+			}
+			if err == nil && *depFiles {
+				err = writeDepFile(outFname, deps)
+			}
+			if err != nil {
+				r.err = fmt.Errorf("error writing dependencies of %s: %w", outFname, err)
+			}
+		}
+	})
+	os.Exit(sve_as.If(failed, 1, 0))
 }
 
 // gnuAsmError tries to assemble the instruction using the system GNU assembler
@@ -1,8 +1,17 @@
 package main
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	sve_as "github.com/fwessels/sve-as"
 	"github.com/google/go-cmp/cmp"
@@ -17,7 +26,7 @@ func TestAsm2s(t *testing.T) {
 		return strings.Join(lines, "\n")
 	}
 	for _, toPlan9s := range []bool{false, true} {
-		got, err := asm2s("test-asm-2-s", []byte(asm), toPlan9s, false)
+		got, err := asm2s("test-asm-2-s", []byte(asm), toPlan9s, false, nil)
 		if err != nil {
 			t.Errorf("%v", err)
 		} else if diff := cmp.Diff(normalize(got), sve_as.If(toPlan9s, normalize(plan9s), normalize(opcodes))); diff != "" {
@@ -26,6 +35,627 @@ func TestAsm2s(t *testing.T) {
 	}
 }
 
+func TestCheckS(t *testing.T) {
+	src := `TEXT ·f(SB), $0
+    WORD $0x04800441 // add z1.s, p1/m, z1.s, z2.s
+    WORD $0x00000000 // add z1.s, p1/m, z1.s, z3.s
+    DWORD $0x0480044304912423 // add z3.s, p1/m, z1.s, z2.s
+    RET
+`
+	drift := checkS("f.s", []byte(src))
+	if len(drift) != 1 || !strings.HasPrefix(drift[0], "f.s:3: have $0x00000000, want $0x04800461") {
+		t.Errorf("unexpected drift: %q", drift)
+	}
+}
+
+func TestCheckStream(t *testing.T) {
+	src := `TEXT ·f(SB), $0
+    WORD $0x00000000 // add z1.s, p1/m, z1.s, z3.s
+    RET
+`
+	drift, err := checkStream([]byte(src))
+	if err != nil || len(drift) != 1 || !strings.HasPrefix(drift[0], "<stdin>:2: have $0x00000000, want $0x04800461") {
+		t.Errorf("unexpected drift: %q, %v", drift, err)
+	}
+	if _, err := checkStream([]byte("TEXT ·f(SB), $0\n    add z1.s, p1/m, z1.s, z3.s\n")); err == nil {
+		t.Errorf("expected error for .asm source")
+	}
+}
+
+func TestCheckAsm(t *testing.T) {
+	dir := t.TempDir()
+	fname, outFname := filepath.Join(dir, "f.asm"), filepath.Join(dir, "f.s")
+	src := []byte("TEXT ·f(SB), $0\n    add x0, x1, x2\n    RET\n")
+	if err := os.WriteFile(fname, src, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if drift := checkAsm(fname, src, outFname, false, false, nil); len(drift) != 1 {
+		t.Errorf("expected missing output to be reported, got %q", drift)
+	}
+	out, err := asm2s(fname, src, false, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(outFname, []byte(out), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if drift := checkAsm(fname, src, outFname, false, false, nil); len(drift) != 0 {
+		t.Errorf("expected no drift, got %q", drift)
+	}
+	src = bytes.ReplaceAll(src, []byte("x2"), []byte("x3"))
+	if drift := checkAsm(fname, src, outFname, false, false, nil); len(drift) != 1 || !strings.HasPrefix(drift[0], outFname+":2:") {
+		t.Errorf("unexpected drift: %q", drift)
+	}
+}
+
+func TestUpToDate(t *testing.T) {
+	dir := t.TempDir()
+	fname, header, outFname := filepath.Join(dir, "f.asm"), filepath.Join(dir, "f.h"), filepath.Join(dir, "f.s")
+	src := []byte("#include \"f.h\"\nTEXT ·f(SB), $0\n    add x0, x1, x2\n    RET\n")
+	for name, content := range map[string][]byte{fname: src, header: []byte("#define FOO 1\n")} {
+		if err := os.WriteFile(name, content, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	options := manifestOptions(nil, false, false, false)
+	if upToDate(outFname, options) {
+		t.Fatalf("expected missing output to be out of date")
+	}
+
+	out, err := asm2s(fname, src, false, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deps, err := asmDependencies(fname, src, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{fname, header}, deps); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if err := os.WriteFile(outFname, []byte(out), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeManifest(outFname, options, deps); err != nil {
+		t.Fatal(err)
+	}
+	if !upToDate(outFname, options) {
+		t.Errorf("expected output to be up to date")
+	}
+	var m manifest
+	if buf, err := os.ReadFile(manifestName(outFname)); err != nil {
+		t.Fatal(err)
+	} else if err := json.Unmarshal(buf, &m); err != nil {
+		t.Fatal(err)
+	}
+	paths := make([]string, 0, len(m.Files))
+	for path := range m.Files {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+	if diff := cmp.Diff([]string{"f.asm", "f.h"}, paths); diff != "" {
+		t.Errorf("manifest paths mismatch (-want +got):\n%s", diff)
+	}
+	if wd, err := os.Getwd(); err != nil {
+		t.Fatal(err)
+	} else if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	} else {
+		defer os.Chdir(wd)
+	}
+	if !upToDate(outFname, options) {
+		t.Errorf("expected output to be up to date from another directory")
+	}
+	if upToDate(outFname, manifestOptions([]string{"BAR=1"}, false, false, false)) {
+		t.Errorf("expected changed defines to make output out of date")
+	}
+	if err := os.WriteFile(header, []byte("#define FOO 2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if upToDate(outFname, options) {
+		t.Errorf("expected changed include to make output out of date")
+	}
+
+	if err := writeDepFile(outFname, deps); err != nil {
+		t.Fatal(err)
+	}
+	d, err := os.ReadFile(filepath.Join(dir, "f.d"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := outFname + ": " + fname + " " + header + "\n\n" + header + ":\n"; string(d) != want {
+		t.Errorf("got %q, want %q", d, want)
+	}
+	for _, target := range []string{"go", "c"} {
+		if err := writeDepFile(targetName(outFname, target), deps); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range []string{"f_opcodes.d", "f.d"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Error(err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "f_opcodes.go.d")); err == nil {
+		t.Errorf("unexpected dependency file f_opcodes.go.d")
+	}
+}
+
+func TestExpandPatterns(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"Kernels/A.asm":             "",
+		"Kernels/Sub/B.asm":         "",
+		"Kernels/Sub/b_arm64.s":     "    WORD $0x00000000 // ret\n",
+		"Kernels/Sub/C_arm64.asm":   "",
+		"Kernels/Sub/C_arm64.s":     "    WORD $0x00000000 // ret\n",
+		"Kernels/Sub/plain_arm64.s": "    RET\n",
+		"Kernels/testdata/D.asm":    "",
+		"Kernels/.hidden/E.asm":     "",
+		"Kernels/_ignored/F.asm":    "",
+		"Kernels/Sub/notes.txt":     "",
+	} {
+		name = filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	root := filepath.Join(dir, "Kernels")
+	sub := filepath.Join(root, "Sub")
+
+	for _, tc := range []struct {
+		args  []string
+		withS bool
+		want  []input
+	}{
+		{[]string{root}, false, []input{{filepath.Join(root, "A.asm"), "."}}},
+		{[]string{root + "/..."}, false, []input{
+			{filepath.Join(root, "A.asm"), "."},
+			{filepath.Join(sub, "B.asm"), "Sub"},
+			{filepath.Join(sub, "C_arm64.asm"), "Sub"},
+		}},
+		{[]string{sub}, true, []input{
+			{filepath.Join(sub, "B.asm"), "."},
+			{filepath.Join(sub, "C_arm64.asm"), "."},
+			{filepath.Join(sub, "b_arm64.s"), "."},
+		}},
+		{[]string{"-", "x.S"}, false, []input{{"-", ""}, {"x.S", ""}}},
+	} {
+		got, err := expandPatterns(tc.args, tc.withS)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(input{})); diff != "" {
+			t.Errorf("%v: mismatch (-want +got):\n%s", tc.args, diff)
+		}
+	}
+
+	if _, err := expandPatterns([]string{filepath.Join(dir, "missing") + "/..."}, false); err == nil {
+		t.Errorf("expected error for missing directory")
+	}
+	if got := outputName(filepath.Join(sub, "B.asm"), "Sub", "out"); got != filepath.Join("out", "Sub", "B.s") {
+		t.Errorf("got %s, want out/Sub/B.s", got)
+	}
+}
+
+func TestRunOrdered(t *testing.T) {
+	const n, j = 20, 3
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+	failed := runOrdered(n, j, &stdout, &stderr, func(i int, r *result) {
+		mu.Lock()
+		running++
+		maxRunning = max(maxRunning, running)
+		mu.Unlock()
+		time.Sleep(time.Duration(n-i) * time.Millisecond) // later inputs finish first
+		fmt.Fprintf(&r.log, "Processing %d\n", i)
+		if i%7 == 6 {
+			r.err = fmt.Errorf("error %d", i)
+		}
+		mu.Lock()
+		running--
+		mu.Unlock()
+	})
+
+	want, wantErr := strings.Builder{}, strings.Builder{}
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&want, "Processing %d\n", i)
+		if i%7 == 6 {
+			fmt.Fprintf(&wantErr, "error %d\n", i)
+		}
+	}
+	if !failed {
+		t.Errorf("expected failure to be reported")
+	}
+	if maxRunning > j {
+		t.Errorf("%d workers running concurrently, want at most %d", maxRunning, j)
+	}
+	if diff := cmp.Diff(want.String(), stdout.String()); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(wantErr.String(), stderr.String()); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	fname := filepath.Join(dir, "f.s")
+	if err := os.WriteFile(fname, []byte("old"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeFileAtomic(fname, []byte("new"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if buf, err := os.ReadFile(fname); err != nil || string(buf) != "new" {
+		t.Errorf("got %q (%v), want \"new\"", buf, err)
+	}
+	if st, err := os.Stat(fname); err != nil || st.Mode().Perm() != 0600 {
+		t.Errorf("expected permissions of rewritten file to be kept")
+	}
+	if entries, err := os.ReadDir(dir); err != nil || len(entries) != 1 {
+		t.Errorf("expected no temporary files to be left behind, got %v", entries)
+	}
+	if err := writeFileAtomic(filepath.Join(dir, "missing", "f.s"), []byte("new"), 0644); err == nil {
+		t.Errorf("expected error writing into missing directory")
+	}
+}
+
+func TestListing(t *testing.T) {
+	dir := t.TempDir()
+	fname := filepath.Join(dir, "k.asm")
+	src := []byte(`#include "m.h"
+
+TEXT ·k(SB), $0
+    add x0, x1, x2 // sum
+loop:
+    ADD3(z3, z1, z2)
+    RET
+`)
+	header := []byte("#define ADD3(a, b, c) \\\n    add a.s, p1/m, b.s, c.s \\\n    sub a.s, a.s, c.s\n")
+	if err := os.WriteFile(filepath.Join(dir, "m.h"), header, 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := listing(fname, src, true, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `                         k.asm:3              TEXT ·k(SB), $0
+0000  8b020020           k.asm:4                  add x0, x1, x2 // sum
+0004                     k.asm:5              loop:
+                         k.asm:6              ADD3(z3, z1, z2)
+0004  04912423 04800443  k.asm:6                => add z3.s, p1/m, z1.s, z2.s
+000c  04a20463           k.asm:6                => sub z3.s, z3.s, z2.s
+0010  (go asm)           k.asm:7                  RET
+`
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if name := listingName(filepath.Join("out", "k.s")); name != filepath.Join("out", "k.lst") {
+		t.Errorf("got %s, want out/k.lst", name)
+	}
+}
+
+func TestListingSameNamedIncludes(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"a/m.h": "    add x0, x1, x2\n",
+		"b/m.h": "    // two\n    sub x0, x1, x2\n",
+	} {
+		if err := os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	src := []byte("TEXT ·k(SB), $0\n#include \"a/m.h\"\n#include \"b/m.h\"\n    RET\n")
+	got, err := listing(filepath.Join(dir, "k.asm"), src, true, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `                         k.asm:1              TEXT ·k(SB), $0
+0000  8b020020           m.h:1                    add x0, x1, x2
+0004  cb020020           m.h:2                    sub x0, x1, x2
+0008  (go asm)           k.asm:4                  RET
+`
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestJSONRecords(t *testing.T) {
+	src := `TEXT ·f(SB), $0
+    WORD $0x00000000 // add z3.s, p1/m, z1.s, z2.s
+    WORD $0x00000000 // fadd d0, d1, d2
+    B done
+    RET
+`
+	got, err := jsonRecords("f.s", []byte(src), false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"input":"f.s","file":"f.s","line":2,"source":"WORD $0x00000000 // add z3.s, p1/m, z1.s, z2.s","instruction":"add z3.s, p1/m, z1.s, z2.s","opcodes":["04912423","04800443"],"movprfx":true,"feature":"sve"}
+{"input":"f.s","file":"f.s","line":3,"source":"WORD $0x00000000 // fadd d0, d1, d2","instruction":"fadd d0, d1, d2","opcodes":["1e622820"],"movprfx":false,"feature":"fp"}
+`
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestEmitTarget(t *testing.T) {
+	src := `TEXT ·f(SB), $0
+    WORD $0x00000000 // add z3.s, p1/m, z1.s, z2.s
+    WORD $0xd503201f
+    RET
+`
+	tests := []struct {
+		target string
+		want   string
+	}{
+		{"go", `// Code generated by sve-as from f.s. DO NOT EDIT.
+
+package kernels
+
+// fOpcodes holds the encoded instructions of routine f
+var fOpcodes = []uint32{
+	0x04912423, // movprfx
+	0x04800443, // add z3.s, p1/m, z1.s, z2.s
+	0xd503201f, // WORD $0xd503201f
+	0xd65f03c0, // RET
+}
+`},
+		{"c", `// Code generated by sve-as from f.s. DO NOT EDIT.
+
+#ifndef F_H
+#define F_H
+
+#include <stdint.h>
+
+static const uint32_t f[] = {
+	0x04912423, // movprfx
+	0x04800443, // add z3.s, p1/m, z1.s, z2.s
+	0xd503201f, // WORD $0xd503201f
+	0xd65f03c0, // RET
+};
+
+#endif // F_H
+`},
+		{"gnu", `// Code generated by sve-as from f.s. DO NOT EDIT.
+
+f:
+	.inst 0x04912423 // movprfx
+	.inst 0x04800443 // add z3.s, p1/m, z1.s, z2.s
+	.inst 0xd503201f // WORD $0xd503201f
+	.inst 0xd65f03c0 // RET
+`},
+		{"bin", "\x23\x24\x91\x04\x43\x04\x80\x04\x1f\x20\x03\xd5\xc0\x03\x5f\xd6"},
+	}
+	for _, tt := range tests {
+		got, err := emitTarget(tt.target, "f.s", []byte(src), false, nil, "kernels")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("%s: mismatch (-want +got):\n%s", tt.target, diff)
+		}
+	}
+
+	if _, err := emitTarget("c", "f.s", []byte("TEXT ·f(SB), $0\n    B done\n"), false, nil, ""); err == nil {
+		t.Error("expected an error for an instruction that is left to the Go assembler")
+	}
+}
+
+func TestAssembleRoutinesBranches(t *testing.T) {
+	src := `TEXT ·loop(SB), NOSPLIT, $0
+    adr x5, done
+loop:
+    add z1.s, p0/m, z1.s, z2.s
+    subs x3, x3, #1
+    b.ge loop
+    cbnz w4, loop
+    tbz x3, #33, loop
+    tbnz w3, #3, done
+    BNE loop
+    CBZ R3, done
+    TBZ $5, R3, loop
+    ADR done, R6
+    JMP loop
+done:
+    RET
+`
+	routines, err := assembleRoutines("loop.asm", []byte(src), true, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// as encoded by llvm-mc
+	want := []uint32{
+		0x10000185, 0x04800041, 0xf1000463, 0x54ffffca, 0x35ffffa4, 0xb60fff83, 0x371800c3,
+		0x54ffff41, 0xb4000083, 0x362fff03, 0x10000046, 0x17fffff6, 0xd65f03c0,
+	}
+	if len(routines) != 1 {
+		t.Fatalf("got %d routines, want 1", len(routines))
+	}
+	if diff := cmp.Diff(want, routines[0].opcodes); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	for _, src := range []string{
+		"TEXT ·f(SB), $0\n    B ·g(SB)\n",
+		"TEXT ·f(SB), $0\nloop:\n    RET\nTEXT ·g(SB), $0\n    BNE loop\n",
+		"TEXT ·f(SB), $0\n    MOVD $1, R0\n",
+	} {
+		if _, err := assembleRoutines("f.asm", []byte(src), true, nil); err == nil {
+			t.Errorf("expected an error for %q", src)
+		}
+	}
+}
+
+func TestAssembleRoutinesWords(t *testing.T) {
+	src := "TEXT ·f(SB), $0\n    WORD $0x12345678\n    WORD $42\n    DWORD $0x0480044304912423\n"
+	routines, err := assembleRoutines("f.s", []byte(src), false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(routines) != 1 {
+		t.Fatalf("got %d routines, want 1", len(routines))
+	}
+	if diff := cmp.Diff([]uint32{0x12345678, 42, 0x04912423, 0x04800443}, routines[0].opcodes); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLabelLiteral(t *testing.T) {
+	src := `TEXT ·f(SB), $0
+    ldr x0, data
+    ldr d1, data
+    ldrsw x2, data
+    ldr q3, data
+    RET
+data:
+    WORD $0
+`
+	out, err := asm2s("f.asm", []byte(src), false, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"    ADR data, R27\n    WORD $0xf9400360 // ldr x0, [x27] /* ldr x0, data */\n",
+		"    ADR data, R27\n    WORD $0x3dc00363 // ldr q3, [x27] /* ldr q3, data */\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+
+	routines, err := assembleRoutines("f.asm", []byte(src), true, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// as encoded by llvm-mc
+	want := []uint32{0x580000a0, 0x5c000081, 0x98000062, 0x9c000043, 0xd65f03c0, 0x00000000}
+	if len(routines) != 1 {
+		t.Fatalf("got %d routines, want 1", len(routines))
+	}
+	if diff := cmp.Diff(want, routines[0].opcodes); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if _, err := assembleRoutines("f.asm", []byte("TEXT ·f(SB), $0\n    ldr x0, data\n    RET\n"), true, nil); err == nil {
+		t.Errorf("expected an error for a label outside the routine")
+	}
+}
+
+func TestAssembleRoutinesAlignment(t *testing.T) {
+	src := `TEXT ·f(SB), $0
+    ptrue p0.s
+    PCALIGN $16
+loop:
+    add z1.s, p0/m, z1.s, z2.s
+    BNE loop
+    PCALIGN $32
+    add z3.s, p1/m, z1.s, z2.s
+    RET
+`
+	routines, err := assembleRoutines("f.asm", []byte(src), true, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lst, err := listing("f.asm", []byte(src), true, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// every instruction of the listing is found at the same offset
+	opcodes := routines[0].opcodes
+	rows := 0
+	for _, row := range strings.Split(lst, "\n") {
+		fields := strings.Fields(row)
+		if len(fields) < 2 || len(fields[0]) != 4 || strings.HasSuffix(row, ":") || fields[1] == "(go" {
+			continue
+		}
+		offset, err := strconv.ParseUint(fields[0], 16, 32)
+		if err != nil {
+			continue
+		}
+		for i, w := range strings.Fields(row[6:25]) {
+			if got := fmt.Sprintf("%08x", opcodes[int(offset)/4+i]); got != w {
+				t.Errorf("offset %04x: got %s, want %s", int(offset)+4*i, got, w)
+			}
+		}
+		rows++
+	}
+	if rows != 3 {
+		t.Errorf("got %d instructions in listing, want 3", rows)
+	}
+	if len(opcodes) != 11 || opcodes[1] != 0xd503201f || opcodes[6] != 0xd503201f {
+		t.Errorf("unexpected padding: %08x", opcodes)
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	a, b := strings.Builder{}, strings.Builder{}
+	for i := 1; i <= 30; i++ {
+		a.WriteString(fmt.Sprintln(i))
+		switch i {
+		case 5:
+			b.WriteString("five\n")
+		case 20:
+		case 25:
+			b.WriteString("x\n")
+		default:
+			b.WriteString(fmt.Sprintln(i))
+		}
+	}
+	b.WriteString("31\n")
+
+	want := "--- a\n+++ b\n" + `@@ -2,7 +2,7 @@
+ 2
+ 3
+ 4
+-5
++five
+ 6
+ 7
+ 8
+@@ -17,14 +17,14 @@
+ 17
+ 18
+ 19
+-20
+ 21
+ 22
+ 23
+ 24
+-25
++x
+ 26
+ 27
+ 28
+ 29
+ 30
++31
+`
+	if diff := cmp.Diff(unifiedDiff("a", "b", a.String(), b.String()), want); diff != "" {
+		t.Errorf("mismatch (-got +want):\n%s", diff)
+	}
+	if got := unifiedDiff("a", "b", a.String(), a.String()); got != "" {
+		t.Errorf("expected no diff, got %q", got)
+	}
+
+	// changes at both ends of a large file must not need a table of all line pairs
+	large := strings.Repeat("    add x0, x1, x2\n", 200000)
+	got := unifiedDiff("a", "b", "TEXT ·f(SB), $0\n"+large+"    RET\n", "TEXT ·g(SB), $0\n"+large+"    RET // done\n")
+	context := strings.Repeat("     add x0, x1, x2\n", 3)
+	want = "--- a\n+++ b\n@@ -1,4 +1,4 @@\n-TEXT ·f(SB), $0\n+TEXT ·g(SB), $0\n" + context + "@@ -199999,4 +199999,4 @@\n" + context + "-    RET\n+    RET // done\n"
+	if got != want {
+		t.Errorf("unexpected diff of large file:\n%s", got)
+	}
+}
+
 const (
 	// #region
 	asm = `
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// result buffers the diagnostics and output of processing a single input, so
+// that they are emitted in input order regardless of which worker finishes first
+type result struct {
+	log    strings.Builder // progress messages and drift reports, printed to stdout
+	stdout string          // diffs and streamed output
+	err    error           // printed to stderr
+	failed bool            // exit with a nonzero status, even without an error
+	done   chan struct{}
+}
+
+// runOrdered processes n inputs using at most j workers, and emits the results
+// of each input as soon as it and all inputs before it have finished. It
+// returns whether processing any of the inputs failed.
+func runOrdered(n, j int, stdout, stderr io.Writer, work func(i int, r *result)) (failed bool) {
+	results := make([]*result, n)
+	for i := range results {
+		results[i] = &result{done: make(chan struct{})}
+	}
+
+	indices := make(chan int)
+	go func() {
+		for i := range results {
+			indices <- i
+		}
+		close(indices)
+	}()
+	for w := 0; w < min(max(j, 1), n); w++ {
+		go func() {
+			for i := range indices {
+				work(i, results[i])
+				close(results[i].done)
+			}
+		}()
+	}
+
+	for _, r := range results {
+		<-r.done
+		fmt.Fprint(stdout, r.log.String(), r.stdout)
+		if r.err != nil {
+			fmt.Fprintln(stderr, r.err)
+		}
+		failed = failed || r.failed || r.err != nil
+	}
+	return
+}
+
+// writeFileAtomic writes a file via a temporary file in the same directory that
+// is renamed into place, so that a failure never leaves a truncated file behind
+func writeFileAtomic(fname string, data []byte, perm os.FileMode) error {
+	f, err := os.CreateTemp(filepath.Dir(fname), "."+filepath.Base(fname)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name()) // no-op once renamed
+	if st, err := os.Stat(fname); err == nil {
+		perm = st.Mode().Perm() // keep the permissions of a file that is rewritten
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Chmod(perm); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), fname)
+}
@@ -0,0 +1,339 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	sve_as "github.com/fwessels/sve-as"
+)
+
+// routine is a TEXT routine assembled into a sequence of opcodes
+type routine struct {
+	name     string
+	opcodes  []uint32
+	comments []string // instruction that each opcode encodes
+}
+
+// targetName returns the name of the file to generate for the given target,
+// based on the name of the .s file
+func targetName(outFname, target string) string {
+	base := strings.TrimSuffix(outFname, filepath.Ext(outFname))
+	switch target {
+	case "go":
+		return base + "_opcodes.go"
+	case "c":
+		return base + ".h"
+	case "gnu":
+		return base + ".inst"
+	case "bin":
+		return base + ".bin"
+	}
+	return outFname
+}
+
+// identifier turns a (routine or directory) name into a valid Go and C identifier
+func identifier(name string) string {
+	name = regexp.MustCompile(`[^A-Za-z0-9_]`).ReplaceAllString(name, "_")
+	if name == "" || '0' <= name[0] && name[0] <= '9' {
+		name = "_" + name
+	}
+	return name
+}
+
+// branch is a PC-relative instruction (b, b.cond, bl, cbz/cbnz, tbz/tbnz or
+// adr) whose target is a label, in either GNU or Plan9 notation
+type branch struct {
+	mnem  string // Plan9 mnemonic, e.g. BNE, CBZW or TBNZ
+	reg   uint32 // Rt/Rd
+	sf    bool   // 64-bit register (cbz/cbnz)
+	bit   uint32 // bit number to test (tbz/tbnz)
+	label string
+}
+
+var branchConds = map[string]uint32{
+	"EQ": 0, "NE": 1, "CS": 2, "HS": 2, "CC": 3, "LO": 3, "MI": 4, "PL": 5, "VS": 6, "VC": 7,
+	"HI": 8, "LS": 9, "GE": 10, "LT": 11, "GT": 12, "LE": 13, "AL": 14, "NV": 15,
+}
+
+// gpRegister parses a general purpose register in GNU (x3, w3, xzr) or Plan9 (R3, ZR) notation
+func gpRegister(reg string) (n uint32, sf bool, ok bool) {
+	reg = strings.ToLower(reg)
+	switch reg {
+	case "xzr", "zr":
+		return 31, true, true
+	case "wzr":
+		return 31, false, true
+	}
+	if len(reg) < 2 || !strings.ContainsAny(reg[:1], "xwr") {
+		return 0, false, false
+	}
+	num, err := strconv.ParseUint(reg[1:], 10, 5)
+	if err != nil || num > 30 {
+		return 0, false, false
+	}
+	return uint32(num), reg[0] != 'w', true
+}
+
+// parseBranch returns the branch for an instruction that branches to (or, for
+// adr, takes the address of) a label
+func parseBranch(code string) (br branch, ok bool) {
+	fields := strings.Fields(strings.ReplaceAll(code, ",", " "))
+	if len(fields) < 2 {
+		return branch{}, false
+	}
+	plan9 := allCaps(fields[0])
+	br.mnem = strings.ToUpper(strings.ReplaceAll(fields[0], ".", ""))
+	args := fields[1:]
+	switch br.mnem {
+	case "B", "BL", "JMP":
+		if len(args) == 1 {
+			br.label = args[0]
+			return br, true
+		}
+	case "CBZ", "CBNZ", "CBZW", "CBNZW":
+		if len(args) == 2 {
+			br.reg, br.sf, ok = gpRegister(args[0])
+			br.sf = br.sf && !strings.HasSuffix(br.mnem, "W")
+			br.label = args[1]
+			return br, ok
+		}
+	case "TBZ", "TBNZ":
+		if len(args) == 3 {
+			bit, reg := args[1], args[0]
+			if plan9 {
+				bit, reg = args[0], args[1]
+			}
+			n, err := strconv.ParseUint(strings.TrimLeft(bit, "#$"), 0, 6)
+			br.reg, _, ok = gpRegister(reg)
+			br.bit, br.label = uint32(n), args[2]
+			return br, ok && err == nil
+		}
+	case "ADR":
+		if len(args) == 2 {
+			reg, label := args[0], args[1]
+			if plan9 {
+				reg, label = args[1], args[0]
+			}
+			br.reg, _, ok = gpRegister(reg)
+			br.label = label
+			return br, ok
+		}
+	default:
+		if _, isCond := branchConds[strings.TrimPrefix(br.mnem, "B")]; isCond && len(args) == 1 && strings.HasPrefix(br.mnem, "B") {
+			br.label = args[0]
+			return br, true
+		}
+	}
+	return branch{}, false
+}
+
+// encode returns the opcode of the branch for a target that is delta bytes away
+func (br branch) encode(delta int) (uint32, error) {
+	inRange := func(bits int) bool { return -(1<<(bits-1)) <= delta>>2 && delta>>2 < 1<<(bits-1) }
+	imm := uint32(delta >> 2)
+	switch br.mnem {
+	case "B", "BL", "JMP":
+		if inRange(26) {
+			return uint32(sve_as.If(br.mnem == "BL", 0x94000000, 0x14000000)) | imm&0x3ffffff, nil
+		}
+	case "CBZ", "CBNZ", "CBZW", "CBNZW":
+		if inRange(19) {
+			opcode := uint32(sve_as.If(strings.HasPrefix(br.mnem, "CBNZ"), 0x35000000, 0x34000000))
+			return opcode | uint32(sve_as.If(br.sf, 1, 0))<<31 | (imm&0x7ffff)<<5 | br.reg, nil
+		}
+	case "TBZ", "TBNZ":
+		if inRange(14) {
+			opcode := uint32(sve_as.If(br.mnem == "TBNZ", 0x37000000, 0x36000000))
+			return opcode | (br.bit>>5)<<31 | (br.bit&31)<<19 | (imm&0x3fff)<<5 | br.reg, nil
+		}
+	case "ADR":
+		if -(1<<20) <= delta && delta < 1<<20 {
+			return 0x10000000 | uint32(delta&3)<<29 | (uint32(delta>>2)&0x7ffff)<<5 | br.reg, nil
+		}
+	default:
+		if inRange(19) {
+			return 0x54000000 | (imm&0x7ffff)<<5 | branchConds[strings.TrimPrefix(br.mnem, "B")], nil
+		}
+	}
+	return 0, fmt.Errorf("branch to %s out of range", br.label)
+}
+
+// assembleRoutines assembles every TEXT routine of an .asm or .s file into a
+// sequence of opcodes. Branches to (and loads from) labels within the routine are resolved,
+// PCALIGN is padded with NOPs (relative to the start of the routine, as for
+// the listing), and other instructions that are left to the Go assembler
+// (such as branches to other symbols) result in an error.
+func assembleRoutines(fname string, buf []byte, isAsm bool, defines []string) (routines []routine, err error) {
+	lines, err := sourceLines(fname, buf, isAsm, defines)
+	if err != nil {
+		return nil, err
+	}
+	textRoutine := regexp.MustCompile(`^TEXT\s+[^·]*·([^(]+)\(SB\)`)
+
+	type fixup struct {
+		index  int // of the opcode to patch
+		origin string
+		label  string
+		encode func(delta int) (uint32, error)
+	}
+	labels := map[string]int{} // word index of every label in the routine
+	var fixups []fixup
+	base := filepath.Base(fname)
+	cur := &routine{name: identifier(strings.TrimSuffix(base, filepath.Ext(base)))}
+	finish := func() error {
+		for _, f := range fixups {
+			target, ok := labels[f.label]
+			if !ok {
+				return fmt.Errorf("%s: cannot encode reference to %s outside routine %s", f.origin, f.label, cur.name)
+			}
+			opcode, err := f.encode((target - f.index) * 4)
+			if err != nil {
+				return fmt.Errorf("%s: %w", f.origin, err)
+			}
+			cur.opcodes[f.index] = opcode
+		}
+		if len(cur.opcodes) > 0 {
+			routines = append(routines, *cur)
+		}
+		labels, fixups = map[string]int{}, nil
+		return nil
+	}
+
+	for _, l := range lines {
+		code, words, size, assembled, err := encodeLine(l.text)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", l.origin, err)
+		}
+		fields := strings.Fields(code)
+		if m := textRoutine.FindStringSubmatch(code); m != nil {
+			if err := finish(); err != nil {
+				return nil, err
+			}
+			cur = &routine{name: identifier(m[1])}
+			continue
+		} else if strings.HasSuffix(code, ":") && len(fields) == 1 {
+			labels[strings.TrimSuffix(code, ":")] = len(cur.opcodes)
+			continue
+		} else if len(fields) > 1 && fields[0] == "PCALIGN" {
+			align, err := strconv.ParseInt(strings.TrimPrefix(fields[1], "$"), 0, 32)
+			if err != nil || align < 4 || align&(align-1) != 0 {
+				return nil, fmt.Errorf("%s: invalid %s", l.origin, code)
+			}
+			for len(cur.opcodes)*4%int(align) != 0 {
+				cur.opcodes = append(cur.opcodes, 0xd503201f)
+				cur.comments = append(cur.comments, "nop ("+code+")")
+			}
+			continue
+		} else if size == 0 {
+			continue
+		}
+
+		var opcodes []uint32
+		switch {
+		case assembled:
+			for _, w := range words {
+				op, _ := strconv.ParseUint(w, 16, 32)
+				opcodes = append(opcodes, uint32(op))
+			}
+		case fields[0] == "WORD" || fields[0] == "DWORD":
+			op, err := strconv.ParseUint(strings.TrimPrefix(fields[1], "$"), 0, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s: invalid %s", l.origin, code)
+			}
+			opcodes = append(opcodes, uint32(op))
+			if size == 8 {
+				opcodes = append(opcodes, uint32(op>>32))
+			}
+		default:
+			if br, ok := parseBranch(code); ok {
+				// patched once all labels of the routine are known
+				fixups = append(fixups, fixup{index: len(cur.opcodes), origin: l.origin, label: br.label, encode: br.encode})
+				opcodes = append(opcodes, 0)
+				break
+			} else if mnem, rt, label, ok := labelLiteral(code); ok {
+				// load (literal) from a label, patched likewise
+				encode := func(delta int) (uint32, error) {
+					opcode, _, err := sve_as.Assemble(fmt.Sprintf("%s %s, #%d", mnem, rt, delta))
+					return opcode, err
+				}
+				fixups = append(fixups, fixup{index: len(cur.opcodes), origin: l.origin, label: label, encode: encode})
+				opcodes = append(opcodes, 0)
+				break
+			}
+			// e.g. RET or NOP in Plan9 notation
+			opcode, opcode2, err := sve_as.Assemble(strings.ToLower(code))
+			if err != nil {
+				return nil, fmt.Errorf("%s: cannot encode %s (left to the Go assembler)", l.origin, code)
+			}
+			opcodes = append(opcodes, opcode)
+			if opcode2 != 0 {
+				opcodes = append(opcodes, opcode2)
+			}
+		}
+		for i, op := range opcodes {
+			cur.opcodes = append(cur.opcodes, op)
+			cur.comments = append(cur.comments, sve_as.If(i == len(opcodes)-1, code, "movprfx"))
+		}
+	}
+	if err := finish(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// emitTarget returns the contents of the file to generate for the given target:
+// a Go source file with a []uint32 per routine, a C header with a static const
+// uint32_t[] per routine, GNU assembler .inst lines, or a flat little-endian binary
+func emitTarget(target, fname string, buf []byte, isAsm bool, defines []string, goPackage string) (string, error) {
+	routines, err := assembleRoutines(fname, buf, isAsm, defines)
+	if err != nil {
+		return "", err
+	}
+
+	out := strings.Builder{}
+	header := fmt.Sprintf("// Code generated by sve-as from %s. DO NOT EDIT.\n", sve_as.If(fname == "-", "<stdin>", filepath.Base(fname)))
+	switch target {
+	case "go":
+		out.WriteString(header + "\npackage " + goPackage + "\n")
+		for _, r := range routines {
+			fmt.Fprintf(&out, "\n// %sOpcodes holds the encoded instructions of routine %s\nvar %sOpcodes = []uint32{\n", r.name, r.name, r.name)
+			for i, op := range r.opcodes {
+				fmt.Fprintf(&out, "\t0x%08x, // %s\n", op, r.comments[i])
+			}
+			out.WriteString("}\n")
+		}
+	case "c":
+		base := filepath.Base(fname)
+		guard := strings.ToUpper(identifier(strings.TrimSuffix(base, filepath.Ext(base)))) + "_H"
+		out.WriteString(header + "\n#ifndef " + guard + "\n#define " + guard + "\n\n#include <stdint.h>\n")
+		for _, r := range routines {
+			fmt.Fprintf(&out, "\nstatic const uint32_t %s[] = {\n", r.name)
+			for i, op := range r.opcodes {
+				fmt.Fprintf(&out, "\t0x%08x, // %s\n", op, r.comments[i])
+			}
+			out.WriteString("};\n")
+		}
+		out.WriteString("\n#endif // " + guard + "\n")
+	case "gnu":
+		out.WriteString(header)
+		for _, r := range routines {
+			fmt.Fprintf(&out, "\n%s:\n", r.name)
+			for i, op := range r.opcodes {
+				fmt.Fprintf(&out, "\t.inst 0x%08x // %s\n", op, r.comments[i])
+			}
+		}
+	case "bin":
+		for _, r := range routines {
+			for _, op := range r.opcodes {
+				out.Write(binary.LittleEndian.AppendUint32(nil, op))
+			}
+		}
+	default:
+		return "", fmt.Errorf("unknown target %q", target)
+	}
+	return out.String(), nil
+}
@@ -9,19 +9,22 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 )
 
 // ---------------- Preprocessor ----------------
 
 type Preprocessor struct {
-	IncludeDirs          []string
-	KeepLineComments     bool
-	KeepIncludeComments  bool
-	EntryFile            string
-	obj               map[string]string
-	fn                map[string]FnMacro
-	includeStackGuard map[string]bool
+	IncludeDirs         []string
+	KeepLineComments    bool
+	FullLineCommentPath bool // write the full path of the file instead of its base name in line comments
+	KeepIncludeComments bool
+	EntryFile           string
+	Includes            []string // resolved paths of all included files, in order of first inclusion
+	obj                 map[string]string
+	fn                  map[string]FnMacro
+	includeStackGuard   map[string]bool
 }
 
 type FnMacro struct {
@@ -161,7 +164,11 @@ func (p *Preprocessor) Process(filename string, r io.Reader, w io.Writer) error
 		}
 		if p.KeepLineComments {
 			// comment marker is safe for Go asm; adjust if you prefer
-			out.WriteString(fmt.Sprintf("// %s:%d\n", shortPath(filename), lineNo))
+			name := shortPath(filename)
+			if p.FullLineCommentPath {
+				name = filename
+			}
+			out.WriteString(fmt.Sprintf("// %s:%d\n", name, lineNo))
 		}
 		out.WriteString(expanded)
 		if !strings.HasSuffix(expanded, "\n") {
@@ -1255,6 +1262,9 @@ func (p *Preprocessor) readInclude(path string, includingFile string) ([]byte, s
 		return nil, "", err
 	}
 	bs, err := os.ReadFile(resolved)
+	if err == nil && !slices.Contains(p.Includes, resolved) {
+		p.Includes = append(p.Includes, resolved)
+	}
 	return bs, resolved, err
 }
 
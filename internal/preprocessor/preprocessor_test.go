@@ -2,6 +2,8 @@ package preprocessor
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -376,3 +378,30 @@ var badLexTests = []badLexTest{
 		"no newline after macro definition",
 	},
 }
+
+func TestProcess_Includes(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"a.h": "#include \"b.h\"\n#define A 1\n",
+		"b.h": "#define B 2\n",
+		"c.h": "#define C 3\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	in := lines(
+		"#include \"a.h\"",
+		"#include \"c.h\"",
+		"MOVD $A, R0",
+	)
+	var out bytes.Buffer
+	pp := NewPreprocessor()
+	if err := pp.Process(filepath.Join(dir, "main.asm"), strings.NewReader(in), &out); err != nil {
+		t.Fatalf("Process error: %v", err)
+	}
+	want := []string{filepath.Join(dir, "a.h"), filepath.Join(dir, "b.h"), filepath.Join(dir, "c.h")}
+	if diff := cmp.Diff(want, pp.Includes); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
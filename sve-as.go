@@ -17,7 +17,9 @@ package sve_as
 
 import (
 	"fmt"
+	"math"
 	"math/bits"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -61,6 +63,18 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 	mnem := strings.Fields(ins)[0]
 	args := normalizeArgsAndBraces(strings.Fields(ins)[1:])
 
+	// Advanced SIMD instructions operate on vector registers with an arrangement
+	for _, arg := range args {
+		if v, _, _ := getVT(strings.Split(arg, "-")[0]); v != -1 { // first register of a range `v0.16b-v3.16b`
+			return assembleNeon(ins, mnem, args)
+		}
+	}
+
+	// Scalar floating-point instructions operate on h, s and d registers
+	if isFpScalar(mnem, args) {
+		return assembleFpScalar(ins, mnem, args)
+	}
+
 	switch mnem {
 	case "add":
 		if ok, rd, rn, rm, shift, imm, sf := is_r_rr(args); ok && 0 <= imm && imm <= 63 {
@@ -94,6 +108,19 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 			return assem_z2_p_z(templ, zdn, pg, zm), 0, nil
 		} else if ok, zd, pg, zn, _, T := is_prefixed_z_p_zz(args); ok {
 			return assem_prefixed_z_p_z(ins, args[1], zd, pg, zn, T)
+		} else if ok, Tza, rv, offs, vgx, zm, nn, T := is_za_z(args); ok && Tza == T && (T == "s" || T == "d") && offs <= 7 {
+			// ADD ZA.T[Wv, offs, VGxN], { Zm1.T-ZmN.T } (array accumulators)
+			templ := "1	1	0	0	0	0	0	1	1	sz	1	0	0	0	0	vg	0	Rv	1	1	1	Zm	0	1	0	off3"
+			templ = strings.ReplaceAll(templ, "sz", If(T == "d", "1", "0"))
+			templ = strings.ReplaceAll(templ, "vg", If(vgx == 4, "1", "0"))
+			templ = strings.ReplaceAll(templ, "Zm", If(vgx == 4, "Zm3	0", "Zm4"))
+			return assem_za(templ, rv, offs, 0, zm/nn), 0, nil
+		} else if ok, Tza, rv, offs, offs2, vgx, zn, _, T, zm := is_za_zz(args); ok && Tza == T && (T == "s" || T == "d") && vgx > 1 && zm < 16 && offs == offs2 && offs <= 7 {
+			// ADD ZA.T[Wv, offs, VGxN], { Zn1.T-ZnN.T }, Zm.T (to array vector, multiple and single vector)
+			return assem_za(sme2ZaTempl(false, vgx, If(T == "d", "1", "0"), "1	1	0", "1	0"), rv, offs, zn, zm), 0, nil
+		} else if ok, Tza, rv, offs, offs2, vgx, zn, zm, nn, T := is_za_zzn(args); ok && Tza == T && (T == "s" || T == "d") && offs == offs2 && offs <= 7 {
+			// ADD ZA.T[Wv, offs, VGxN], { Zn1.T-ZnN.T }, { Zm1.T-ZmN.T } (to array vector, multiple vectors)
+			return assem_za(sme2ZaTempl(true, vgx, If(T == "d", "1", "0"), "1	1	0", "1	0"), rv, offs, zn/nn, zm/nn), 0, nil
 		}
 	case "adds":
 		if ok, rd, rn, rm, shift, imm, sf := is_r_rr(args); ok && 0 <= imm && imm <= 63 {
@@ -127,9 +154,35 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 			return assem_r_rr(templ, rd, rn, rm, sf, "", 0), 0, nil
 		}
 	case "udiv":
-		if ok, rd, rn, rm, res0a, res0b, sf := is_r_rr(args); ok && res0a == 0 && res0b == 0 {
+		if ok, rd, rn, rm, res0a, res0b, sf := is_r_rr(args); ok && res0a == 0 && res0b == 0 && isSameWidth(args...) {
 			templ := "sf	0	0	1	1	0	1	0	1	1	0	Rm	0	0	0	0	1	0	Rn	Rd"
 			return assem_r_rr(templ, rd, rn, rm, sf, "", 0), 0, nil
+		} else if ok, zdn, pg, zm, T := is_z_p_zz(args); ok && !is_zeroing(args[1]) {
+			if strings.ToLower(T) == "d" || strings.ToLower(T) == "s" {
+				// udiv only defined for 64- and 32-bit
+				templ := "0	0	0	0	0	1	0	0	size	0	1	0	1	0	1	0	0	0	Pg	Zm	Zdn"
+				templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
+				return assem_z2_p_z(templ, zdn, pg, zm), 0, nil
+			}
+		} else if ok, zd, pg, zn, _, T := is_prefixed_z_p_zz(args); ok {
+			if strings.ToLower(T) == "d" || strings.ToLower(T) == "s" {
+				// udiv only defined for 64- and 32-bit
+				return assem_prefixed_z_p_z(ins, args[1], zd, pg, zn, T)
+			}
+		}
+	case "udivr":
+		if ok, zdn, pg, zm, T := is_z_p_zz(args); ok && !is_zeroing(args[1]) {
+			if strings.ToLower(T) == "d" || strings.ToLower(T) == "s" {
+				// udivr only defined for 64- and 32-bit
+				templ := "0	0	0	0	0	1	0	0	size	0	1	0	1	1	1	0	0	0	Pg	Zm	Zdn"
+				templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
+				return assem_z2_p_z(templ, zdn, pg, zm), 0, nil
+			}
+		} else if ok, zd, pg, zn, _, T := is_prefixed_z_p_zz(args); ok {
+			if strings.ToLower(T) == "d" || strings.ToLower(T) == "s" {
+				// udivr only defined for 64- and 32-bit
+				return assem_prefixed_z_p_z(ins, args[1], zd, pg, zn, T)
+			}
 		}
 	case "subs":
 		if ok, rd, rn, rm, shift, imm, sf := is_r_rr(args); ok && 0 <= imm && imm <= 63 {
@@ -182,6 +235,24 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 			ra := 31
 			return assem_r_rrr(templ, rd, rn, rm, ra, sf), 0, nil
 		}
+	case "smaddl", "umaddl", "smsubl", "umsubl":
+		// SMADDL <Xd>, <Wn>, <Wm>, <Xa>
+		if ok, rd, rn, rm, ra, _ := is_r_rrr(args); ok && isSameWidth(args[0], args[3], "x0") && isSameWidth(args[1], args[2], "w0") {
+			templ := "1	0	0	1	1	0	1	1	U	0	1	Rm	o0	Ra	Rn	Rd"
+			templ = strings.ReplaceAll(templ, "U", If(mnem[0] == 'u', "1", "0"))
+			templ = strings.ReplaceAll(templ, "o0", If(strings.HasSuffix(mnem, "subl"), "1", "0"))
+			return assem_r_rrr(templ, rd, rn, rm, ra, 1), 0, nil
+		}
+	case "smull", "umull", "smnegl", "umnegl":
+		// SMULL <Xd>, <Wn>, <Wm>
+		// is equivalent to
+		// SMADDL <Xd>, <Wn>, <Wm>, XZR
+		if ok, rd, rn, rm, shift, imm, _ := is_r_rr(args); ok && shift == 0 && imm == 0 && isSameWidth(args[0], "x0") && isSameWidth(args[1], args[2], "w0") {
+			templ := "1	0	0	1	1	0	1	1	U	0	1	Rm	o0	Ra	Rn	Rd"
+			templ = strings.ReplaceAll(templ, "U", If(mnem[0] == 'u', "1", "0"))
+			templ = strings.ReplaceAll(templ, "o0", If(strings.HasSuffix(mnem, "negl"), "1", "0"))
+			return assem_r_rrr(templ, rd, rn, rm, 31, 1), 0, nil
+		}
 	case "saddv", "uaddv": // add across vector (signed/unsigned)
 		if ok, vd, pg, zn, T := is_v_p_z(args); ok && T != "" {
 			templ := "0	0	0	0	0	1	0	0	size	0	0	0	0	0	U	0	0	1	Pg	Zn	Vd"
@@ -213,13 +284,32 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 			}
 		}
 	case "fmaxnmv", "fminnmv":
-		if ok, vd, pg, zn, T := is_v_p_z(args); ok && T != "b" {
+		if ok, vd, pg, zn, T := is_v_p_z(args); ok && T != "b" && args[0][:1] == T {
 			templ := "0	1	1	0	0	1	0	1	size	0	0	0	1	0	N	0	0	1	Pg	Zn	Vd"
 			templ = strings.ReplaceAll(templ, "N", If(mnem == "fminnmv", "1", "0"))
 			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
 			templ = strings.ReplaceAll(templ, "Vd", "Rd")
 			return assem_r_p_z(templ, vd, pg, zn), 0, nil
 		}
+	case "faddv", "fmaxv", "fminv":
+		if ok, vd, pg, zn, T := is_v_p_z(args); ok && T != "b" && args[0][:1] == T {
+			// FADDV <V><d>, <Pg>, <Zn>.<T> — recursive (tree-order) FP add reduction
+			// FMAXV/FMINV <V><d>, <Pg>, <Zn>.<T>
+			var opc string
+			switch mnem {
+			case "faddv":
+				opc = "0	0	0"
+			case "fmaxv":
+				opc = "1	1	0"
+			case "fminv":
+				opc = "1	1	1"
+			}
+			templ := "0	1	1	0	0	1	0	1	size	0	0	0	opc	0	0	1	Pg	Zn	Vd"
+			templ = strings.ReplaceAll(templ, "opc", opc)
+			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
+			templ = strings.ReplaceAll(templ, "Vd", "Rd")
+			return assem_r_p_z(templ, vd, pg, zn), 0, nil
+		}
 	case "andv", "orv", "eorv":
 		if ok, vd, pg, zn, T := is_v_p_z(args); ok && T != "" {
 			// ANDV/ORV/EORV <Vd>, <Pg>, <Zn>.<T>
@@ -427,6 +517,68 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
 			return assem_z_zz(templ, zd, zn, zm), 0, nil
 		}
+	case "tblq":
+		if len(args) == 5 && args[1] == "{" && args[3] == "}" {
+			// TBLQ <Zd>.<T>, { <Zn>.<T> }, <Zm>.<T> — table lookup within each 128-bit segment
+			if ok, zd, zn, zm, T := is_z_zz([]string{args[0], args[2], args[4]}); ok && T != "q" {
+				templ := "0	1	0	0	0	1	0	0	size	0	Zm	1	1	1	1	1	0	Zn	Zd"
+				templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
+				return assem_z_zz(templ, zd, zn, zm), 0, nil
+			}
+		}
+	case "tbxq":
+		if ok, zd, zn, zm, T := is_z_zz(args); ok && T != "q" {
+			// TBXQ <Zd>.<T>, <Zn>.<T>, <Zm>.<T> — table lookup extension within each 128-bit segment
+			templ := "0	0	0	0	0	1	0	1	size	1	Zm	0	0	1	1	0	1	Zn	Zd"
+			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
+			return assem_z_zz(templ, zd, zn, zm), 0, nil
+		}
+	case "dupq":
+		if ok, zd, zn, index, T := is_z_zindexed(args); ok {
+			// DUPQ <Zd>.<T>, <Zn>.<T>[<imm>] — broadcast indexed element within each 128-bit segment
+			var i5tsz int
+			switch T {
+			case "b":
+				i5tsz = If(index < 16, index<<1|1, 0)
+			case "h":
+				i5tsz = If(index < 8, index<<2|2, 0)
+			case "s":
+				i5tsz = If(index < 4, index<<3|4, 0)
+			case "d":
+				i5tsz = If(index < 2, index<<4|8, 0)
+			}
+			if i5tsz != 0 {
+				templ := "0	0	0	0	0	1	0	1	0	0	1	i5tsz	0	0	1	0	0	1	Zn	Zd"
+				templ = strings.ReplaceAll(templ, "i5tsz", fmt.Sprintf("%0*s", 5, strconv.FormatUint(uint64(i5tsz), 2)))
+				return assem_z_z(templ, zd, zn), 0, nil
+			}
+		}
+	case "extq":
+		if ok, zd, _, zm, imm, consec, T := is_z_zzi(args); ok && !consec && T == "b" && 0 <= imm && imm <= 15 {
+			// EXTQ <Zdn>.B, <Zdn>.B, <Zm>.B, #<imm> — extract vector segment from pair
+			templ := "0	0	0	0	0	1	0	1	0	1	1	0	imm4	0	0	1	0	0	1	Zm	Zdn"
+			templ = strings.ReplaceAll(templ, "imm4", fmt.Sprintf("%0*s", 4, strconv.FormatUint(uint64(imm), 2)))
+			return assem_z_zzi(templ, zd, zm), 0, nil
+		}
+	case "zipq1", "zipq2", "uzpq1", "uzpq2":
+		if ok, zd, zn, zm, T := is_z_zz(args); ok && T != "q" {
+			// ZIPQ1/ZIPQ2/UZPQ1/UZPQ2 <Zd>.<T>, <Zn>.<T>, <Zm>.<T> — within each 128-bit segment
+			var op string
+			switch mnem {
+			case "zipq1":
+				op = "0	0"
+			case "zipq2":
+				op = "0	1"
+			case "uzpq1":
+				op = "1	0"
+			case "uzpq2":
+				op = "1	1"
+			}
+			templ := "0	1	0	0	0	1	0	0	size	0	Zm	1	1	1	0	op	Zn	Zd"
+			templ = strings.ReplaceAll(templ, "op", op)
+			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
+			return assem_z_zz(templ, zd, zn, zm), 0, nil
+		}
 	case "dupm": // duplicate with (contiguous) bit mask
 		if ok, zd, imm, T := is_z_i(args); ok {
 			if immr, imms := parseBitfieldConst(uint64(imm)); immr != 0xffffffff {
@@ -628,14 +780,39 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 			return assem_z_p_z(templ, zd, pg, zn), 0, nil
 		}
 	case "fmov":
-		if ok, rd, rn, sf := is_r_v(args); ok && sf == 1 {
-			templ := "sf	0	0	1	1	1	1	0	ftype	1	rmode	opcode	0	0	0	0	0	0	Rn	Rd"
-			// FMOV <Xd>, <Dn>
-			// Double-precision to 64-bit (sf == 1 && ftype == 01 && rmode == 00 && opcode == 110)
-			templ = strings.ReplaceAll(templ, "ftype", "01")
-			templ = strings.ReplaceAll(templ, "rmode", "00")
-			templ = strings.ReplaceAll(templ, "opcode", "110")
-			return assem_r_ri(templ, rd, rn, sf, "", 0, 0), 0, nil
+		if ok, zd, f, T := is_z_f(args); ok && T != "b" && T != "" {
+			if f == 0 && !math.Signbit(f) {
+				// FMOV <Zd>.<T>, #0.0
+				// is equivalent to
+				// DUP <Zd>.<T>, #0{, <shift>}
+				templ := "0	0	1	0	0	1	0	1	size	1	1	1	0	0	0	1	1	0	imm8	Zd"
+				templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
+				return assem_z_i(templ, zd, "imm8", 0), 0, nil
+			}
+			// FMOV <Zd>.<T>, #<const>
+			// is equivalent to
+			// FDUP <Zd>.<T>, #<const>
+			if ok, _ := getFpImm8(f); !ok {
+				return 0, 0, errFpImm8(ins)
+			}
+			return Assemble(strings.Replace(ins, "fmov", "fdup", 1))
+		} else if ok, zd, pg, f, T := is_z_p_f(args); ok && T != "b" && T != "" && !is_zeroing(args[1]) {
+			if f == 0 && !math.Signbit(f) {
+				// FMOV <Zd>.<T>, <Pg>/M, #0.0
+				// is equivalent to
+				// CPY <Zd>.<T>, <Pg>/M, #0{, <shift>}
+				templ := "0	0	0	0	0	1	0	1	size	0	1	Pg	0	1	0	imm8	Zd"
+				templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
+				templ = strings.ReplaceAll(templ, "Pg", fmt.Sprintf("%0*s", 4, strconv.FormatUint(uint64(pg), 2)))
+				return assem_z_i(templ, zd, "imm8", 0), 0, nil
+			}
+			// FMOV <Zd>.<T>, <Pg>/M, #<const>
+			// is equivalent to
+			// FCPY <Zd>.<T>, <Pg>/M, #<const>
+			if ok, _ := getFpImm8(f); !ok {
+				return 0, 0, errFpImm8(ins)
+			}
+			return Assemble(strings.Replace(ins, "fmov", "fcpy", 1))
 		}
 	case "abs":
 		if ok, rd, rn, shift, imm, sf := is_r_r(args); ok && len(args) == 2 && shift == 0 && imm == 0 {
@@ -689,7 +866,7 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 			return assem_r_ri(templ, rd, rn, sf, "", 0, 0), 0, nil
 		}
 	case "cls":
-		if ok, rd, rn, shift, imm, sf := is_r_r(args); ok && len(args) == 2 && shift == 0 && imm == 0 {
+		if ok, rd, rn, shift, imm, sf := is_r_r(args); ok && len(args) == 2 && shift == 0 && imm == 0 && isSameWidth(args...) {
 			templ := "sf	1	0	1	1	0	1	0	1	1	0	0	0	0	0	0	0	0	0	1	0	1	Rn	Rd"
 			return assem_r_ri(templ, rd, rn, sf, "", 0, 0), 0, nil
 		} else if ok, zd, pg, zn, T := is_z_p_z(args); ok {
@@ -859,161 +1036,44 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 			return assem_r_i(templ, rd, "immhi", imm>>2), 0, nil
 		}
 	case "ldr":
-		if ok, zt, xn, imm := is_z_bi(args); ok && -256 <= imm && imm < 256 {
+		if len(args) >= 2 && args[0] == "zt0" {
+			// LDR ZT0, [Xn]
+			if rn, imm := getMemAddrImm(args[1:]); rn != -1 && imm == 0 {
+				templ := "1	1	1	0	0	0	0	1	0	0	0	1	1	1	1	1	1	0	0	0	0	0	Rn	0	0	0	0	0"
+				return assem_r(templ, rn), 0, nil
+			}
+		} else if ok, zt, xn, imm := is_z_bi(args); ok && -256 <= imm && imm < 256 {
 			templ := "1	0	0	0	0	1	0	1	1	0	imm9h	0	1	0	imm9l	Rn	Zt"
 			return assem_z_bi(templ, zt, xn, imm), 0, nil
 		} else if ok, pt, xn, imm := is_p_bi(args); ok && -256 <= imm && imm < 256 {
 			templ := "1	0	0	0	0	1	0	1	1	0	imm9h	0	0	0	imm9l	Rn	0	Pt"
 			return assem_p_bi(templ, pt, xn, imm), 0, nil
-		} else if ok, rt, rn, rm, option, amount, sf := is_r_br(args); ok {
-			if option == 3 {
-				templ := "1	x	1	1	1	0	0	0	0	1	1	Rm	option	S	1	0	Rn	Rt"
-				templ = strings.ReplaceAll(templ, "Rt", "Rd")
-				templ = strings.ReplaceAll(templ, "option", fmt.Sprintf("%0*s", 3, strconv.FormatUint(uint64(option), 2)))
-				s := -1
-				if amount == 0 {
-					s = 0
-				} else if sf == 0 && amount == 2 || sf == 1 && amount == 3 {
-					s = 1
-				}
-				if s != -1 {
-					templ = strings.ReplaceAll(templ, "S", fmt.Sprintf("%0*s", 1, strconv.FormatUint(uint64(s), 2)))
-					templ = strings.ReplaceAll(templ, "x", "sf")
-					return assem_r_rr(templ, rt, rn, rm, sf, "", 0), 0, nil
-				}
-			}
-		} else if ok, rt, rn, imm, sf, postIndex, writeBack := is_r_bi(args); ok {
-			if writeBack {
-				if -256 <= imm && imm <= 255 {
-					var templ string
-					if postIndex {
-						templ = "1	x	1	1	1	0	0	0	0	1	0	imm9	0	1	Rn	Rt"
-					} else {
-						templ = "1	x	1	1	1	0	0	0	0	1	0	imm9	1	1	Rn	Rt"
-					}
-					templ = strings.ReplaceAll(templ, "Rt", "Rd")
-					imm = If(imm < 0, (1<<9)+imm, imm)
-					templ = strings.ReplaceAll(templ, "x", "sf")
-					return assem_r_ri(templ, rt, rn, sf, "imm9", imm, 0), 0, nil
-				}
-			} else {
-				// unsigned offset
-				if imm&7 == 0 && imm >= 0 && imm < 32768 {
-					templ := "1	x	1	1	1	0	0	1	0	1	imm12	Rn	Rt"
-					templ = strings.ReplaceAll(templ, "Rt", "Rd")
-					templ = strings.ReplaceAll(templ, "x", "sf")
-					return assem_r_ri(templ, rt, rn, sf, "imm12", imm/8, 0), 0, nil
-				}
-			}
-		}
-	case "ldrsb", "ldrb", "strb":
-		if ok, rt, rn, imm, sf, postIndex, writeBack := is_r_bi(args); ok && sf == 1 {
-			opc := -1
-			switch mnem {
-			case "ldrsb": // sign-extends to 64 bits
-				opc = 2
-			case "ldrb": // unsigned
-				opc = 1
-			case "strb":
-				opc = 0
-			}
-			if writeBack {
-				if -256 <= imm && imm <= 255 {
-					var templ string
-					if postIndex {
-						templ = "0	0	1	1	1	0	0	0	opc	0	imm9	0	1	Rn	Rt"
-					} else {
-						templ = "0	0	1	1	1	0	0	0	opc	0	imm9	1	1	Rn	Rt"
-					}
-					templ = strings.ReplaceAll(templ, "Rt", "Rd")
-					templ = strings.ReplaceAll(templ, "opc", fmt.Sprintf("%0*s", 2, strconv.FormatUint(uint64(opc), 2)))
-					imm = If(imm < 0, (1<<9)+imm, imm)
-					return assem_r_ri(templ, rt, rn, sf /*ignored*/, "imm9", imm, 0), 0, nil
-				}
-			} else {
-				// unsigned offset
-				if imm >= 0 && imm < 4096 {
-					templ := "0	0	1	1	1	0	0	1	opc	imm12	Rn	Rt"
-					templ = strings.ReplaceAll(templ, "Rt", "Rd")
-					templ = strings.ReplaceAll(templ, "opc", fmt.Sprintf("%0*s", 2, strconv.FormatUint(uint64(opc), 2)))
-					return assem_r_ri(templ, rt, rn, sf /*ignored*/, "imm12", imm, 0), 0, nil
-				}
-			}
+		} else if ok, size, opc, v, scale := getLoadStoreType(mnem, args); ok {
+			return assem_ldst(ins, size, opc, v, scale, false, args)
 		}
 	case "str":
-		if ok, zt, xn, imm := is_z_bi(args); ok && -256 <= imm && imm < 256 {
+		if len(args) >= 2 && args[0] == "zt0" {
+			// STR ZT0, [Xn]
+			if rn, imm := getMemAddrImm(args[1:]); rn != -1 && imm == 0 {
+				templ := "1	1	1	0	0	0	0	1	0	0	1	1	1	1	1	1	1	0	0	0	0	0	Rn	0	0	0	0	0"
+				return assem_r(templ, rn), 0, nil
+			}
+		} else if ok, zt, xn, imm := is_z_bi(args); ok && -256 <= imm && imm < 256 {
 			templ := "1	1	1	0	0	1	0	1	1	0	imm9h	0	1	0	imm9l	Rn	Zt"
 			return assem_z_bi(templ, zt, xn, imm), 0, nil
 		} else if ok, pt, xn, imm := is_p_bi(args); ok && -256 <= imm && imm < 256 {
 			templ := "1	1	1	0	0	1	0	1	1	0	imm9h	0	0	0	imm9l	Rn	0	Pt"
 			return assem_p_bi(templ, pt, xn, imm), 0, nil
-		} else if ok, rt, rn, rm, option, amount, sf := is_r_br(args); ok {
-			if option == 3 {
-				templ := "1	x	1	1	1	0	0	0	0	0	1	Rm	option	S	1	0	Rn	Rt"
-				templ = strings.ReplaceAll(templ, "Rt", "Rd")
-				templ = strings.ReplaceAll(templ, "option", fmt.Sprintf("%0*s", 3, strconv.FormatUint(uint64(option), 2)))
-				s := -1
-				if amount == 0 {
-					s = 0
-				} else if sf == 0 && amount == 2 || sf == 1 && amount == 3 {
-					s = 1
-				}
-				if s != -1 {
-					templ = strings.ReplaceAll(templ, "S", fmt.Sprintf("%0*s", 1, strconv.FormatUint(uint64(s), 2)))
-					templ = strings.ReplaceAll(templ, "x", "sf")
-					return assem_r_rr(templ, rt, rn, rm, sf, "", 0), 0, nil
-				}
-			}
-		} else if ok, rt, rn, imm, sf, postIndex, writeBack := is_r_bi(args); ok {
-			if writeBack {
-				if -256 <= imm && imm <= 255 {
-					var templ string
-					if postIndex {
-						templ = "1	x	1	1	1	0	0	0	0	0	0	imm9	0	1	Rn	Rt"
-					} else {
-						templ = "1	x	1	1	1	0	0	0	0	0	0	imm9	1	1	Rn	Rt"
-					}
-					templ = strings.ReplaceAll(templ, "Rt", "Rd")
-					imm = If(imm < 0, (1<<9)+imm, imm)
-					templ = strings.ReplaceAll(templ, "x", "sf")
-					return assem_r_ri(templ, rt, rn, sf, "imm9", imm, 0), 0, nil
-				}
-			} else {
-				if imm&7 == 0 && imm >= 0 && imm < 32768 {
-					templ := "1	x	1	1	1	0	0	1	0	0	imm12	Rn	Rt"
-					templ = strings.ReplaceAll(templ, "Rt", "Rd")
-					templ = strings.ReplaceAll(templ, "x", "1")
-					return assem_r_ri(templ, rt, rn, sf /*ignored*/, "imm12", imm/8, 0), 0, nil
-				}
-			}
+		} else if ok, size, opc, v, scale := getLoadStoreType(mnem, args); ok {
+			return assem_ldst(ins, size, opc, v, scale, false, args)
 		}
-	case "ldursw", "ldur", "ldurh", "ldurb", "stur", "sturh", "sturb":
-		if ok, rt, rn, imm, sf, postIndex, writeBack := is_r_bi(args); ok && !postIndex && !writeBack && -256 <= imm && imm <= 255 {
-			size, opc := -1, -1
-			switch mnem {
-			case "ldursw":
-				size, opc = 3, 2
-			case "ldur":
-				size, opc = 3, 1
-			case "ldurh":
-				size, opc = 1, 1
-			case "ldurb":
-				size, opc = 0, 1
-			case "stur":
-				size, opc = 3, 0
-			case "sturh":
-				size, opc = 1, 0
-			case "sturb":
-				size, opc = 0, 0
-			}
-			if size != -1 && opc != -1 {
-				templ := "size	1	1	1	0	0	0	opc	0	imm9	0	0	Rn	Rt"
-				templ = strings.ReplaceAll(templ, "size", fmt.Sprintf("%0*s", 2, strconv.FormatUint(uint64(size), 2)))
-				templ = strings.ReplaceAll(templ, "opc", fmt.Sprintf("%0*s", 2, strconv.FormatUint(uint64(opc), 2)))
-				templ = strings.ReplaceAll(templ, "Rt", "Rd")
-				imm = If(imm < 0, (1<<9)+imm, imm)
-				return assem_r_ri(templ, rt, rn, sf /*ignored*/, "imm9", imm, 0), 0, nil
-			}
+	case "ldrb", "ldrsb", "strb", "ldrh", "ldrsh", "strh", "ldrsw", "ldrw", "strw":
+		if ok, size, opc, v, scale := getLoadStoreType(mnem, args); ok {
+			return assem_ldst(ins, size, opc, v, scale, false, args)
+		}
+	case "ldur", "ldurb", "ldursb", "ldurh", "ldursh", "ldursw", "stur", "sturb", "sturh":
+		if ok, size, opc, v, scale := getLoadStoreType(mnem, args); ok {
+			return assem_ldst(ins, size, opc, v, scale, true, args)
 		}
 	case "ld1d":
 		if ok, zt, pg, rn, zm, xs, T := is_z_p_bz(args); ok && strings.ToLower(T) == "d" {
@@ -1362,98 +1422,32 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 			imm = If(imm < 0, (1<<4)+imm/4, imm/4)
 			return assem_z_p_bi(ldstMultiTempl(4, T, true, false), zt, pg, rn, "imm4", imm), 0, nil
 		}
-	case "ldrsh", "ldrh", "strh":
-		if ok, rt, rn, imm, sf, postIndex, writeBack := is_r_bi(args); ok {
-			opc := -1
-			switch mnem {
-			case "ldrsh": // sign-extends to 64 bits
-				opc = 2
-			case "ldrh": // unsigned
-				opc = 1
-			case "strh":
-				opc = 0
-			}
-			if writeBack {
-				if -256 <= imm && imm <= 255 {
-					var templ string
-					if postIndex {
-						templ = "0	1	1	1	1	0	0	0	opc	0	imm9	0	1	Rn	Rt"
-					} else {
-						templ = "0	1	1	1	1	0	0	0	opc	0	imm9	1	1	Rn	Rt"
-					}
-					templ = strings.ReplaceAll(templ, "Rt", "Rd")
-					templ = strings.ReplaceAll(templ, "opc", fmt.Sprintf("%0*s", 2, strconv.FormatUint(uint64(opc), 2)))
-					imm = If(imm < 0, (1<<9)+imm, imm)
-					return assem_r_ri(templ, rt, rn, sf /*ignored*/, "imm9", imm, 0), 0, nil
-				}
-			} else {
-				// unsigned offset
-				if imm&1 == 0 && imm >= 0 && imm < 8192 {
-					templ := "0	1	1	1	1	0	0	1	opc	imm12	Rn	Rt"
-					templ = strings.ReplaceAll(templ, "Rt", "Rd")
-					templ = strings.ReplaceAll(templ, "opc", fmt.Sprintf("%0*s", 2, strconv.FormatUint(uint64(opc), 2)))
-					return assem_r_ri(templ, rt, rn, sf /*ignored*/, "imm12", imm/2, 0), 0, nil
-				}
-			}
-		}
-	case "ldrsw", "ldrw", "strw": // additional scalar instruction (in style of ldrb/ldrh and strb/strh)
-		if ok, rt, rn, imm, sf, postIndex, writeBack := is_r_bi(args); ok {
-			opc := -1
-			switch mnem {
-			case "ldrsw": // sign-extends to 64 bits
-				opc = 2
-			case "ldrw": // unsigned
-				opc = 1
-			case "strw":
-				opc = 0
-			}
-			if writeBack {
-				if -256 <= imm && imm <= 255 {
-					var templ string
-					if postIndex {
-						templ = "1	x	1	1	1	0	0	0	opc	0	imm9	0	1	Rn	Rt"
-					} else {
-						templ = "1	x	1	1	1	0	0	0	opc	0	imm9	1	1	Rn	Rt"
-					}
-					templ = strings.ReplaceAll(templ, "Rt", "Rd")
-					templ = strings.ReplaceAll(templ, "x", "0") // all 32-bit registers
-					templ = strings.ReplaceAll(templ, "opc", fmt.Sprintf("%0*s", 2, strconv.FormatUint(uint64(opc), 2)))
-					imm = If(imm < 0, (1<<9)+imm, imm)
-					return assem_r_ri(templ, rt, rn, sf /*ignored*/, "imm9", imm, 0), 0, nil
-				}
-			} else {
-				// unsigned offset
-				if imm&3 == 0 && imm >= 0 && imm < 16384 {
-					templ := "1	x	1	1	1	0	0	1	opc	imm12	Rn	Rt"
-					templ = strings.ReplaceAll(templ, "Rt", "Rd")
-					templ = strings.ReplaceAll(templ, "x", "0")
-					templ = strings.ReplaceAll(templ, "opc", fmt.Sprintf("%0*s", 2, strconv.FormatUint(uint64(opc), 2)))
-					return assem_r_ri(templ, rt, rn, sf /*ignored*/, "imm12", imm/4, 0), 0, nil
-				}
-			}
-		}
-	case "ldp", "stp":
-		if ok, rt, rt2, rn, imm, postIndex, writeBack := is_rr_bi(args); ok {
-			if imm&7 == 0 && -512 <= imm && imm <= 504 {
-				imm = If(imm < 0, (1<<7)+imm/8, imm/8)
-				L := If(mnem == "ldp", "1", "0")
-				if writeBack {
-					var templ string
-					if postIndex {
-						templ = "x	0	1	0	1	0	0	0	1	L	imm7	Rt2	Rn	Rt"
-					} else {
-						templ = "x	0	1	0	1	0	0	1	1	L	imm7	Rt2	Rn	Rt"
-					}
-					templ = strings.ReplaceAll(templ, "x", "1")
-					templ = strings.ReplaceAll(templ, "L", L)
-					return assem_rr_ri(templ, rt, rt2, rn, "imm7", imm), 0, nil
-				} else {
-					// signed offset
-					templ := "x	0	1	0	1	0	0	1	0	L	imm7	Rt2	Rn	Rt"
-					templ = strings.ReplaceAll(templ, "x", "1")
-					templ = strings.ReplaceAll(templ, "L", L)
-					return assem_rr_ri(templ, rt, rt2, rn, "imm7", imm), 0, nil
-				}
+	case "ldp", "stp", "ldnp", "stnp", "ldpsw":
+		nonTemporal := mnem == "ldnp" || mnem == "stnp"
+		if ok, rt, rt2, rn, imm, postIndex, writeBack := is_rr_bi(args); ok && isSameWidth(args[0], args[1]) && !(nonTemporal && writeBack) {
+			// LDPSW <Xt1>, <Xt2>, ... loads a pair of sign-extended words
+			opc, scale := If(args[0][0] == 'x', "1	0", "0	0"), If(args[0][0] == 'x', 3, 2)
+			if mnem == "ldpsw" {
+				opc, scale = If(args[0][0] == 'x', "0	1", ""), 2
+			}
+			if opc != "" && imm&(1<<scale-1) == 0 && -64<<scale <= imm && imm < 64<<scale {
+				imm = If(imm < 0, (1<<7)+imm>>scale, imm>>scale)
+				templ := "opc	1	0	1	0	idx	L	imm7	Rt2	Rn	Rt"
+				templ = strings.ReplaceAll(templ, "opc", opc)
+				templ = strings.ReplaceAll(templ, "idx", getPairIndexMode(nonTemporal, postIndex, writeBack))
+				templ = strings.ReplaceAll(templ, "L", If(mnem[0] == 'l', "1", "0"))
+				return assem_rr_ri(templ, rt, rt2, rn, "imm7", imm), 0, nil
+			}
+		} else if ok, vt, vt2, rn, imm, width, postIndex, writeBack := is_vv_bi(args); ok && mnem != "ldpsw" && !(nonTemporal && writeBack) {
+			// LDP <Qt1>, <Qt2>, ... (SIMD&FP register pairs)
+			opc, scale := map[byte]string{'s': "0	0", 'd': "0	1", 'q': "1	0"}[width], getFpScale(width)
+			if opc != "" && imm&(1<<scale-1) == 0 && -64<<scale <= imm && imm < 64<<scale {
+				imm = If(imm < 0, (1<<7)+imm>>scale, imm>>scale)
+				templ := "opc	1	0	1	1	idx	L	imm7	Rt2	Rn	Rt"
+				templ = strings.ReplaceAll(templ, "opc", opc)
+				templ = strings.ReplaceAll(templ, "idx", getPairIndexMode(nonTemporal, postIndex, writeBack))
+				templ = strings.ReplaceAll(templ, "L", If(mnem[0] == 'l', "1", "0"))
+				return assem_rr_ri(templ, vt, vt2, rn, "imm7", imm), 0, nil
 			}
 		}
 	case "lsr":
@@ -1622,19 +1616,41 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 			templ = strings.ReplaceAll(templ, "sf", fmt.Sprintf("%0*s", 1, strconv.FormatUint(uint64(sf), 2)))
 			return assem_p_rr(templ, pd, rn, rm), 0, nil
 		}
-	case "eor3":
+	case "eor3", "bcax", "bsl", "bsl1n", "bsl2n", "nbsl":
 		if ok, zd, zn, zm, za, T := is_z_zzz(args); ok && strings.ToUpper(T) == "D" {
-			templ := "0	0	0	0	0	1	0	0	0	0	1	Zm	0	0	1	1	1	0	Zk	Zdn"
+			// <Zdn>.D, <Zdn>.D, <Zm>.D, <Zk>.D: 00000100 opc 1 Zm 0011 1 o2 Zk Zdn
+			var opc, o2 string
+			switch mnem {
+			case "eor3":
+				opc, o2 = "0	0", "0"
+			case "bsl":
+				opc, o2 = "0	0", "1"
+			case "bcax":
+				opc, o2 = "0	1", "0"
+			case "bsl1n":
+				opc, o2 = "0	1", "1"
+			case "bsl2n":
+				opc, o2 = "1	0", "1"
+			case "nbsl":
+				opc, o2 = "1	1", "1"
+			}
+			templ := "0	0	0	0	0	1	0	0	opc	1	Zm	0	0	1	1	1	o2	Zk	Zdn"
+			templ = strings.ReplaceAll(templ, "opc", opc)
+			templ = strings.ReplaceAll(templ, "o2", o2)
 			if zd == zn {
 				return assem_z2_zz(templ, zd, zm, za), 0, nil
+			} else if zm != zd && za != zd && zm != zn && za != zn {
+				// we need to use a prefix
+				return assem_prefixed_z_z(ins, zd, zn)
 			}
 		}
-	case "bcax":
-		if ok, zd, zn, zm, za, T := is_z_zzz(args); ok && strings.ToUpper(T) == "D" {
-			templ := "0	0	0	0	0	1	0	0	0	1	1	Zm	0	0	1	1	1	0	Zk	Zdn"
-			if zd == zn {
-				return assem_z2_zz(templ, zd, zm, za), 0, nil
-			}
+	case "eorbt", "eortb":
+		if ok, zd, zn, zm, T := is_z_zz(args); ok {
+			// EORBT/EORTB <Zd>.<T>, <Zn>.<T>, <Zm>.<T> — interleaving exclusive OR (bottom/top)
+			templ := "0	1	0	0	0	1	0	1	size	0	Zm	1	0	0	1	0	tb	Zn	Zd"
+			templ = strings.ReplaceAll(templ, "tb", If(mnem == "eortb", "1", "0"))
+			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
+			return assem_z_zz(templ, zd, zn, zm), 0, nil
 		}
 	case "bdep", "bext", "bgrp":
 		if ok, zd, zn, zm, T := is_z_zz(args); ok {
@@ -1644,20 +1660,54 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
 			return assem_z_zz(templ, zd, zn, zm), 0, nil
 		}
-	case "mad":
-		if ok, zdn, pg, zm, za, T := is_z2_p_zz(args); ok {
-			templ := "0	0	0	0	0	1	0	0	size	0	Zm	1	1	0	Pg	Za	Zdn"
+	case "mad", "msb":
+		if ok, zdn, pg, zm, za, T := is_z2_p_zz(args); ok && !is_zeroing(args[1]) {
+			// MAD/MSB <Zdn>.<T>, <Pg>/M, <Zm>.<T>, <Za>.<T>
+			templ := "0	0	0	0	0	1	0	0	size	0	Zm	1	1	op	Pg	Za	Zdn"
+			templ = strings.ReplaceAll(templ, "op", If(mnem == "msb", "1", "0"))
 			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
 			return assem_z2_p_zz(templ, zdn, pg, zm, za), 0, nil
+		} else if ok && zm != zdn && za != zdn {
+			// zeroing predication is handled via MOVPRFX
+			return assem_prefixed_z_p_z(ins, args[1], zdn, pg, zdn, T)
 		}
-	case "mls":
-		if ok, zda, pg, zn, zm, T := is_z2_p_zz(args); ok {
-			templ := "0	0	0	0	0	1	0	0	size	0	Zm	0	1	1	Pg	Zn	Zda"
+	case "mla", "mls":
+		if ok, zda, zn, zm, index, T := is_z_zz_indexed(args); ok {
+			// MLA/MLS <Zda>.<T>, <Zn>.<T>, <Zm>.<T>[<imm>] (indexed)
+			var templ string
+			switch T {
+			case "h":
+				if zm < 8 && index < 8 {
+					templ = "0	1	0	0	0	1	0	0	0	i3h	1	i3l	Zm3	0	0	0	0	1	op	Zn	Zda"
+					templ = strings.ReplaceAll(templ, "i3h", fmt.Sprintf("%0*s", 1, strconv.FormatUint(uint64(index>>2), 2)))
+					templ = strings.ReplaceAll(templ, "i3l", fmt.Sprintf("%0*s", 2, strconv.FormatUint(uint64(index&3), 2)))
+				}
+			case "s":
+				if zm < 8 && index < 4 {
+					templ = "0	1	0	0	0	1	0	0	1	0	1	i2	Zm3	0	0	0	0	1	op	Zn	Zda"
+					templ = strings.ReplaceAll(templ, "i2", fmt.Sprintf("%0*s", 2, strconv.FormatUint(uint64(index), 2)))
+				}
+			case "d":
+				if zm < 16 && index < 2 {
+					templ = "0	1	0	0	0	1	0	0	1	1	1	i1	Zm4	0	0	0	0	1	op	Zn	Zda"
+					templ = strings.ReplaceAll(templ, "i1", fmt.Sprintf("%0*s", 1, strconv.FormatUint(uint64(index), 2)))
+				}
+			}
+			if templ != "" {
+				templ = strings.ReplaceAll(templ, "op", If(mnem == "mls", "1", "0"))
+				templ = strings.ReplaceAll(templ, "Zm3", fmt.Sprintf("%0*s", 3, strconv.FormatUint(uint64(zm), 2)))
+				templ = strings.ReplaceAll(templ, "Zm4", fmt.Sprintf("%0*s", 4, strconv.FormatUint(uint64(zm), 2)))
+				return assem_z_p_zz(templ, zda, 0, zn, 0), 0, nil
+			}
+		} else if ok, zda, pg, zn, zm, T := is_z2_p_zz(args); ok && !is_zeroing(args[1]) {
+			// MLA/MLS <Zda>.<T>, <Pg>/M, <Zn>.<T>, <Zm>.<T>
+			templ := "0	0	0	0	0	1	0	0	size	0	Zm	0	1	op	Pg	Zn	Zda"
+			templ = strings.ReplaceAll(templ, "op", If(mnem == "mls", "1", "0"))
 			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
-			templ = strings.ReplaceAll(templ, "Zda", "Zdn")
-			templ = strings.ReplaceAll(templ, "Zm", "Za")
-			templ = strings.ReplaceAll(templ, "Zn", "Zm")
-			return assem_z2_p_zz(templ, zda, pg, zn, zm), 0, nil
+			return assem_z_p_zz(templ, zda, pg, zn, zm), 0, nil
+		} else if ok && zn != zda && zm != zda {
+			// zeroing predication is handled via MOVPRFX
+			return assem_prefixed_z_p_z(ins, args[1], zda, pg, zda, T)
 		}
 	case "compact":
 		if ok, zd, pg, zn, T := is_z_p_z(args); ok {
@@ -1666,37 +1716,61 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 			return assem_z_p_z(templ, zd, pg, zn), 0, nil
 		}
 	case "zip1":
-		if ok, zd, zn, zm, T := is_z_zz(args); ok {
+		if ok, zd, zn, zm, T := is_z_zz(args); ok && T == "q" {
+			// ZIP1 <Zd>.Q, <Zn>.Q, <Zm>.Q (128-bit elements)
+			templ := "0	0	0	0	0	1	0	1	1	0	1	Zm	0	0	0	0	0	0	Zn	Zd"
+			return assem_z_zz(templ, zd, zn, zm), 0, nil
+		} else if ok, zd, zn, zm, T := is_z_zz(args); ok {
 			templ := "0	0	0	0	0	1	0	1	size	1	Zm	0	1	1	0	0	0	Zn	Zd"
 			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
 			return assem_z_zz(templ, zd, zn, zm), 0, nil
 		}
 	case "zip2":
-		if ok, zd, zn, zm, T := is_z_zz(args); ok {
+		if ok, zd, zn, zm, T := is_z_zz(args); ok && T == "q" {
+			// ZIP2 <Zd>.Q, <Zn>.Q, <Zm>.Q (128-bit elements)
+			templ := "0	0	0	0	0	1	0	1	1	0	1	Zm	0	0	0	0	0	1	Zn	Zd"
+			return assem_z_zz(templ, zd, zn, zm), 0, nil
+		} else if ok, zd, zn, zm, T := is_z_zz(args); ok {
 			templ := "0	0	0	0	0	1	0	1	size	1	Zm	0	1	1	0	0	1	Zn	Zd"
 			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
 			return assem_z_zz(templ, zd, zn, zm), 0, nil
 		}
 	case "uzp1":
-		if ok, zd, zn, zm, T := is_z_zz(args); ok {
+		if ok, zd, zn, zm, T := is_z_zz(args); ok && T == "q" {
+			// UZP1 <Zd>.Q, <Zn>.Q, <Zm>.Q (128-bit elements)
+			templ := "0	0	0	0	0	1	0	1	1	0	1	Zm	0	0	0	0	1	0	Zn	Zd"
+			return assem_z_zz(templ, zd, zn, zm), 0, nil
+		} else if ok, zd, zn, zm, T := is_z_zz(args); ok {
 			templ := "0	0	0	0	0	1	0	1	size	1	Zm	0	1	1	0	1	0	Zn	Zd"
 			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
 			return assem_z_zz(templ, zd, zn, zm), 0, nil
 		}
 	case "uzp2":
-		if ok, zd, zn, zm, T := is_z_zz(args); ok {
+		if ok, zd, zn, zm, T := is_z_zz(args); ok && T == "q" {
+			// UZP2 <Zd>.Q, <Zn>.Q, <Zm>.Q (128-bit elements)
+			templ := "0	0	0	0	0	1	0	1	1	0	1	Zm	0	0	0	0	1	1	Zn	Zd"
+			return assem_z_zz(templ, zd, zn, zm), 0, nil
+		} else if ok, zd, zn, zm, T := is_z_zz(args); ok {
 			templ := "0	0	0	0	0	1	0	1	size	1	Zm	0	1	1	0	1	1	Zn	Zd"
 			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
 			return assem_z_zz(templ, zd, zn, zm), 0, nil
 		}
 	case "trn1":
-		if ok, zd, zn, zm, T := is_z_zz(args); ok {
+		if ok, zd, zn, zm, T := is_z_zz(args); ok && T == "q" {
+			// TRN1 <Zd>.Q, <Zn>.Q, <Zm>.Q (128-bit elements)
+			templ := "0	0	0	0	0	1	0	1	1	0	1	Zm	0	0	0	1	1	0	Zn	Zd"
+			return assem_z_zz(templ, zd, zn, zm), 0, nil
+		} else if ok, zd, zn, zm, T := is_z_zz(args); ok {
 			templ := "0	0	0	0	0	1	0	1	size	1	Zm	0	1	1	1	0	0	Zn	Zd"
 			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
 			return assem_z_zz(templ, zd, zn, zm), 0, nil
 		}
 	case "trn2":
-		if ok, zd, zn, zm, T := is_z_zz(args); ok {
+		if ok, zd, zn, zm, T := is_z_zz(args); ok && T == "q" {
+			// TRN2 <Zd>.Q, <Zn>.Q, <Zm>.Q (128-bit elements)
+			templ := "0	0	0	0	0	1	0	1	1	0	1	Zm	0	0	0	1	1	1	Zn	Zd"
+			return assem_z_zz(templ, zd, zn, zm), 0, nil
+		} else if ok, zd, zn, zm, T := is_z_zz(args); ok {
 			templ := "0	0	0	0	0	1	0	1	size	1	Zm	0	1	1	1	0	1	Zn	Zd"
 			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
 			return assem_z_zz(templ, zd, zn, zm), 0, nil
@@ -1722,6 +1796,12 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
 			return assem_z_p_z(templ, zd, pg, zn), 0, nil
 		}
+	case "revd":
+		if ok, zd, pg, zn, T := is_z_p_z(args); ok && T == "q" && !is_zeroing(args[1]) {
+			// REVD <Zd>.Q, <Pg>/M, <Zn>.Q — reverse 64-bit doublewords in each 128-bit element
+			templ := "0	0	0	0	0	1	0	1	0	0	1	0	1	1	1	0	1	0	0	Pg	Zn	Zd"
+			return assem_z_p_z(templ, zd, pg, zn), 0, nil
+		}
 	case "revh":
 		if ok, zd, pg, zn, T := is_z_p_z(args); ok {
 			templ := "0	0	0	0	0	1	0	1	size	1	0	0	1	0	1	1	0	0	Pg	Zn	Zd"
@@ -1745,18 +1825,168 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 				templ = strings.ReplaceAll(templ, "size", "10")
 				return assem_z_zz2(templ, zda, zn, zm), 0, nil
 			}
+		} else if ok, Tza, rv, offs, offs2, vgx, zn, _, T, zm := is_za_zz(args); ok && vgx > 1 && zm < 16 && offs == offs2 && offs <= 7 {
+			// SDOT/UDOT ZA.T[Wv, offs, VGxN], { Zn1.Tb-ZnN.Tb }, Zm.Tb (multiple and single vector)
+			if sz, op, valid := sme2DotSpecifier(mnem, Tza, T); valid {
+				return assem_za(sme2ZaTempl(false, vgx, sz, "1	0	1", op), rv, offs, zn, zm), 0, nil
+			}
+		} else if ok, Tza, rv, offs, offs2, vgx, zn, zm, nn, T := is_za_zzn(args); ok && offs == offs2 && offs <= 7 {
+			// SDOT/UDOT ZA.T[Wv, offs, VGxN], { Zn1.Tb-ZnN.Tb }, { Zm1.Tb-ZmN.Tb } (multiple vectors)
+			if sz, op, valid := sme2DotSpecifier(mnem, Tza, T); valid {
+				return assem_za(sme2ZaTempl(true, vgx, sz, "1	0	1", op), rv, offs, zn/nn, zm/nn), 0, nil
+			}
+		}
+	case "fdot":
+		if ok, Tza, rv, offs, offs2, vgx, zn, _, T, zm := is_za_zz(args); ok && Tza == "s" && T == "h" && vgx > 1 && zm < 16 && offs == offs2 && offs <= 7 {
+			// FDOT ZA.S[Wv, offs, VGxN], { Zn1.H-ZnN.H }, Zm.H (multiple and single vector)
+			return assem_za(sme2ZaTempl(false, vgx, "0", "1	0	0", "0	0"), rv, offs, zn, zm), 0, nil
+		} else if ok, Tza, rv, offs, offs2, vgx, zn, zm, nn, T := is_za_zzn(args); ok && Tza == "s" && T == "h" && offs == offs2 && offs <= 7 {
+			// FDOT ZA.S[Wv, offs, VGxN], { Zn1.H-ZnN.H }, { Zm1.H-ZmN.H } (multiple vectors)
+			return assem_za(sme2ZaTempl(true, vgx, "0", "1	0	0", "0	0"), rv, offs, zn/nn, zm/nn), 0, nil
+		}
+	case "fmlal":
+		// the ZA offset denotes a pair of vectors (e.g. `za.s[w8, 0:1]`) and is encoded as offs/2
+		if ok, Tza, rv, offs, offs2, vgx, zn, _, T, zm := is_za_zz(args); ok && Tza == "s" && T == "h" && zm < 16 && offs&1 == 0 && offs2 == offs+1 {
+			if vgx == 1 && offs <= 14 {
+				// FMLAL ZA.S[Wv, offs1:offs2], Zn.H, Zm.H (single vector)
+				return assem_za(sme2ZaTempl(false, vgx, "0", "0	1	1", "0	0"), rv, offs/2, zn, zm), 0, nil
+			} else if vgx > 1 && offs <= 6 {
+				// FMLAL ZA.S[Wv, offs1:offs2, VGxN], { Zn1.H-ZnN.H }, Zm.H (multiple and single vector)
+				return assem_za(sme2ZaTempl(false, vgx, "0", "0	1	0", "0	0"), rv, offs/2, zn, zm), 0, nil
+			}
+		} else if ok, Tza, rv, offs, offs2, vgx, zn, zm, nn, T := is_za_zzn(args); ok && Tza == "s" && T == "h" && offs&1 == 0 && offs2 == offs+1 && offs <= 6 {
+			// FMLAL ZA.S[Wv, offs1:offs2, VGxN], { Zn1.H-ZnN.H }, { Zm1.H-ZmN.H } (multiple vectors)
+			return assem_za(sme2ZaTempl(true, vgx, "0", "0	1	0", "0	0"), rv, offs/2, zn/nn, zm/nn), 0, nil
+		}
+	case "zero":
+		if len(args) == 3 && args[0] == "{" && args[1] == "zt0" && args[2] == "}" {
+			// ZERO { ZT0 }
+			templ := "1	1	0	0	0	0	0	0	0	1	0	0	1	0	0	0	0	0	0	0	0	0	0	0	0	0	0	0	0	0	0	1"
+			templ = strings.ReplaceAll(templ, "\t", "")
+			if code, err := strconv.ParseUint(templ, 2, 32); err == nil {
+				return uint32(code), 0, nil
+			}
+		}
+	case "movt":
+		// MOVT Xt, ZT0[offs] and MOVT ZT0[offs], Xt (offs is a byte offset, a multiple of 8)
+		if len(args) == 2 {
+			toTable := strings.HasPrefix(args[0], "zt0[")
+			rt, table := getR(args[If(toTable, 1, 0)]), args[If(toTable, 0, 1)]
+			if strings.HasPrefix(table, "zt0[") && strings.HasSuffix(table, "]") && rt != -1 && args[If(toTable, 1, 0)][0] == 'x' {
+				if offs, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSuffix(strings.TrimPrefix(table, "zt0["), "]"), "#")); err == nil && offs&7 == 0 && 0 <= offs && offs <= 56 {
+					templ := "1	1	0	0	0	0	0	0	0	1	0	0	1	1	dir	0	0	off3	0	0	1	1	1	1	1	Rt"
+					templ = strings.ReplaceAll(templ, "dir", If(toTable, "1", "0"))
+					templ = strings.ReplaceAll(templ, "off3", fmt.Sprintf("%0*s", 3, strconv.FormatUint(uint64(offs/8), 2)))
+					templ = strings.ReplaceAll(templ, "Rt", fmt.Sprintf("%0*s", 5, strconv.FormatUint(uint64(rt), 2)))
+					templ = strings.ReplaceAll(templ, "\t", "")
+					if code, err := strconv.ParseUint(templ, 2, 32); err == nil {
+						return uint32(code), 0, nil
+					}
+				}
+			}
+		}
+	case "luti2", "luti4":
+		// LUTI2 Zd.T, ZT0, Zn[index] and LUTI4 Zd.T, ZT0, Zn[index]
+		if len(args) == 5 && args[0] == "{" && args[2] == "}" {
+			args = []string{args[1], args[3], args[4]} // single-register list `{ Zd.T }`
+		}
+		if len(args) == 3 && args[1] == "zt0" {
+			zd, T, _ := getZ(args[0])
+			reg, index, _ := strings.Cut(args[2], "[")
+			zn, Tn, _ := getZ(reg)
+			if i, err := strconv.Atoi(strings.TrimSuffix(index, "]")); err == nil && zd != -1 && zn != -1 && Tn == "" && (T == "b" || T == "h" || T == "s") {
+				var templ string
+				if mnem == "luti2" && 0 <= i && i <= 15 {
+					templ = "1	1	0	0	0	0	0	0	1	1	0	0	1	1	i4	size	0	0	Zn	Zd"
+					templ = strings.ReplaceAll(templ, "i4", fmt.Sprintf("%0*s", 4, strconv.FormatUint(uint64(i), 2)))
+				} else if mnem == "luti4" && 0 <= i && i <= 7 {
+					templ = "1	1	0	0	0	0	0	0	1	1	0	0	1	0	1	i3	size	0	0	Zn	Zd"
+					templ = strings.ReplaceAll(templ, "i3", fmt.Sprintf("%0*s", 3, strconv.FormatUint(uint64(i), 2)))
+				}
+				if templ != "" {
+					templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
+					return assem_z_zz(templ, zd, zn, 0), 0, nil
+				}
+			}
 		}
 	case "fcvt":
 		if ok, zd, pg, zn, Td, Tn := is_z_p_z_tt(args); ok {
-			if Td == "s" && Tn == "h" {
-				templ := "0	1	1	0	0	1	0	1	1	0	0	0	1	0	0	1	1	0	1	Pg	Zn	Zd"
-				return assem_z_p_z(templ, zd, pg, zn), 0, nil
+			// FCVT <Zd>.<Td>, <Pg>/M, <Zn>.<Tn>
+			var opc, opc2 string
+			switch Td + Tn {
+			case "hs":
+				opc, opc2 = "10", "00"
+			case "sh":
+				opc, opc2 = "10", "01"
+			case "hd":
+				opc, opc2 = "11", "00"
+			case "dh":
+				opc, opc2 = "11", "01"
+			case "sd":
+				opc, opc2 = "11", "10"
+			case "ds":
+				opc, opc2 = "11", "11"
+			default:
+				return 0, 0, errSizePair(mnem, "h/s, s/h, h/d, d/h, s/d, d/s", ins)
 			}
+			templ := "0	1	1	0	0	1	0	1	opc	0	0	1	0	opc2	1	0	1	Pg	Zn	Zd"
+			templ = strings.ReplaceAll(templ, "opc2", opc2)
+			templ = strings.ReplaceAll(templ, "opc", opc)
+			return assem_z_p_z(templ, zd, pg, zn), 0, nil
+		}
+	case "fcvtx":
+		if ok, zd, pg, zn, Td, Tn := is_z_p_z_tt(args); ok {
+			// FCVTX <Zd>.S, <Pg>/M, <Zn>.D — round to odd
+			if Td+Tn != "sd" {
+				return 0, 0, errSizePair(mnem, "s/d", ins)
+			}
+			templ := "0	1	1	0	0	1	0	1	0	0	0	0	1	0	1	0	1	0	1	Pg	Zn	Zd"
+			return assem_z_p_z(templ, zd, pg, zn), 0, nil
+		}
+	case "fcvtnt", "fcvtlt", "fcvtxnt":
+		if ok, zd, pg, zn, Td, Tn := is_z_p_z_tt(args); ok {
+			// FCVTNT <Zd>.<T>, <Pg>/M, <Zn>.<Tb> — narrow to top (odd) elements
+			// FCVTLT <Zd>.<T>, <Pg>/M, <Zn>.<Tb> — widen from top (odd) elements
+			// FCVTXNT <Zd>.S, <Pg>/M, <Zn>.D — narrow to top (odd) elements, round to odd
+			var opc, opc2, pairs string
+			switch mnem + Td + Tn {
+			case "fcvtnths":
+				opc, opc2 = "10", "00"
+			case "fcvtntsd":
+				opc, opc2 = "11", "10"
+			case "fcvtltsh":
+				opc, opc2 = "10", "01"
+			case "fcvtltds":
+				opc, opc2 = "11", "11"
+			case "fcvtxntsd":
+				opc, opc2 = "00", "10"
+			}
+			if opc == "" {
+				switch mnem {
+				case "fcvtnt":
+					pairs = "h/s, s/d"
+				case "fcvtlt":
+					pairs = "s/h, d/s"
+				case "fcvtxnt":
+					pairs = "s/d"
+				}
+				return 0, 0, errSizePair(mnem, pairs, ins)
+			}
+			templ := "0	1	1	0	0	1	0	0	opc	0	0	1	0	opc2	1	0	1	Pg	Zn	Zd"
+			templ = strings.ReplaceAll(templ, "opc2", opc2)
+			templ = strings.ReplaceAll(templ, "opc", opc)
+			return assem_z_p_z(templ, zd, pg, zn), 0, nil
 		}
 	case "fcvtzs", "fcvtzu":
 		if ok, zd, pg, zn, Td, Tn := is_z_p_z_tt(args); ok {
 			var opc, sf string
 			switch Td + Tn {
+			case "hh":
+				opc, sf = "01", "01"
+			case "sh":
+				opc, sf = "01", "10"
+			case "dh":
+				opc, sf = "01", "11"
 			case "dd":
 				opc, sf = "11", "11"
 			case "ss":
@@ -1765,13 +1995,21 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 				opc, sf = "11", "00"
 			case "ds":
 				opc, sf = "11", "10"
+			default:
+				return 0, 0, errSizePair(mnem, "h/h, s/h, d/h, s/s, d/s, s/d, d/d", ins)
 			}
-			if opc != "" {
-				templ := "0	1	1	0	0	1	0	1	opc	0	1	1	sf	U	1	0	1	Pg	Zn	Zd"
-				templ = strings.ReplaceAll(templ, "opc", opc)
-				templ = strings.ReplaceAll(templ, "sf", sf)
+			templ := "0	1	1	0	0	1	0	1	opc	0	1	1	sf	U	1	0	1	Pg	Zn	Zd"
+			templ = strings.ReplaceAll(templ, "opc", opc)
+			templ = strings.ReplaceAll(templ, "sf", sf)
+			templ = strings.ReplaceAll(templ, "U", If(mnem == "fcvtzu", "1", "0"))
+			return assem_z_p_z(templ, zd, pg, zn), 0, nil
+		} else if ok, rd, vn, sf := is_r_v(args); ok {
+			// FCVTZS <Wd|Xd>, <Hn|Sn|Dn> (scalar, round towards zero)
+			if ftype := getFpType(args[1]); ftype != "" {
+				templ := "sf	0	0	1	1	1	1	0	ftype	1	1	1	0	0	U	0	0	0	0	0	0	Rn	Rd"
+				templ = strings.ReplaceAll(templ, "ftype", ftype)
 				templ = strings.ReplaceAll(templ, "U", If(mnem == "fcvtzu", "1", "0"))
-				return assem_z_p_z(templ, zd, pg, zn), 0, nil
+				return assem_r_ri(templ, rd, vn, sf, "", 0, 0), 0, nil
 			}
 		}
 	case "fadd":
@@ -1787,6 +2025,11 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 			return assem_z2_p_z(templ, zdn, pg, zm), 0, nil
 		} else if ok, zd, pg, zn, _, T := is_prefixed_z_p_zz(args); ok && T != "b" {
 			return assem_prefixed_z_p_z(ins, args[1], zd, pg, zn, T)
+		} else if ok, zd, pg, zn, f, T := is_z_p_zf(args); ok && T != "b" {
+			if zd != zn || is_zeroing(args[1]) {
+				return assem_prefixed_z_p_z(ins, args[1], zd, pg, zn, T)
+			}
+			return assem_z_p_fimm1(ins, mnem, zd, pg, f, T)
 		}
 	case "fsub":
 		if ok, zd, zn, zm, T := is_z_zz(args); ok && T != "b" {
@@ -1801,9 +2044,14 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 			return assem_z2_p_z(templ, zdn, pg, zm), 0, nil
 		} else if ok, zd, pg, zn, _, T := is_prefixed_z_p_zz(args); ok && T != "b" {
 			return assem_prefixed_z_p_z(ins, args[1], zd, pg, zn, T)
+		} else if ok, zd, pg, zn, f, T := is_z_p_zf(args); ok && T != "b" {
+			if zd != zn || is_zeroing(args[1]) {
+				return assem_prefixed_z_p_z(ins, args[1], zd, pg, zn, T)
+			}
+			return assem_z_p_fimm1(ins, mnem, zd, pg, f, T)
 		}
 	case "fdiv":
-		if ok, zdn, pg, zm, T := is_z_p_zz(args); !is_zeroing(args[1]) && ok && T != "b" {
+		if ok, zdn, pg, zm, T := is_z_p_zz(args); ok && !is_zeroing(args[1]) && T != "b" {
 			// FDIV <Zdn>.<T>, <Pg>/M, <Zdn>.<T>, <Zm>.<T>
 			templ := "0	1	1	0	0	1	0	1	size	0	0	1	1	0	1	1	0	0	Pg	Zm	Zdn"
 			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
@@ -1811,6 +2059,15 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 		} else if ok, zd, pg, zn, _, T := is_prefixed_z_p_zz(args); ok && T != "b" {
 			return assem_prefixed_z_p_z(ins, args[1], zd, pg, zn, T)
 		}
+	case "fdivr":
+		if ok, zdn, pg, zm, T := is_z_p_zz(args); ok && !is_zeroing(args[1]) && T != "b" {
+			// FDIVR <Zdn>.<T>, <Pg>/M, <Zdn>.<T>, <Zm>.<T> — reversed divide
+			templ := "0	1	1	0	0	1	0	1	size	0	0	1	1	0	0	1	0	0	Pg	Zm	Zdn"
+			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
+			return assem_z2_p_z(templ, zdn, pg, zm), 0, nil
+		} else if ok, zd, pg, zn, _, T := is_prefixed_z_p_zz(args); ok && T != "b" {
+			return assem_prefixed_z_p_z(ins, args[1], zd, pg, zn, T)
+		}
 	case "fmax":
 		if ok, zdn, pg, zm, T := is_z_p_zz(args); !is_zeroing(args[1]) && ok && T != "b" {
 			// FMAX <Zdn>.<T>, <Pg>/M, <Zdn>.<T>, <Zm>.<T>
@@ -1819,6 +2076,11 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 			return assem_z2_p_z(templ, zdn, pg, zm), 0, nil
 		} else if ok, zd, pg, zn, _, T := is_prefixed_z_p_zz(args); ok && T != "b" {
 			return assem_prefixed_z_p_z(ins, args[1], zd, pg, zn, T)
+		} else if ok, zd, pg, zn, f, T := is_z_p_zf(args); ok && T != "b" {
+			if zd != zn || is_zeroing(args[1]) {
+				return assem_prefixed_z_p_z(ins, args[1], zd, pg, zn, T)
+			}
+			return assem_z_p_fimm1(ins, mnem, zd, pg, f, T)
 		}
 	case "fmin":
 		if ok, zdn, pg, zm, T := is_z_p_zz(args); !is_zeroing(args[1]) && ok && T != "b" {
@@ -1828,6 +2090,60 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 			return assem_z2_p_z(templ, zdn, pg, zm), 0, nil
 		} else if ok, zd, pg, zn, _, T := is_prefixed_z_p_zz(args); ok && T != "b" {
 			return assem_prefixed_z_p_z(ins, args[1], zd, pg, zn, T)
+		} else if ok, zd, pg, zn, f, T := is_z_p_zf(args); ok && T != "b" {
+			if zd != zn || is_zeroing(args[1]) {
+				return assem_prefixed_z_p_z(ins, args[1], zd, pg, zn, T)
+			}
+			return assem_z_p_fimm1(ins, mnem, zd, pg, f, T)
+		}
+	case "fmaxnm", "fminnm", "fsubr", "fabd", "fmulx":
+		if ok, zdn, pg, zm, T := is_z_p_zz(args); ok && !is_zeroing(args[1]) && T != "b" {
+			// <Zdn>.<T>, <Pg>/M, <Zdn>.<T>, <Zm>.<T>: 01100101 size 00 opc 100 Pg Zm Zdn
+			var opc string
+			switch mnem {
+			case "fsubr":
+				opc = "0	0	1	1"
+			case "fmaxnm":
+				opc = "0	1	0	0"
+			case "fminnm":
+				opc = "0	1	0	1"
+			case "fabd":
+				opc = "1	0	0	0"
+			case "fmulx":
+				opc = "1	0	1	0"
+			}
+			templ := "0	1	1	0	0	1	0	1	size	0	0	opc	1	0	0	Pg	Zm	Zdn"
+			templ = strings.ReplaceAll(templ, "opc", opc)
+			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
+			return assem_z2_p_z(templ, zdn, pg, zm), 0, nil
+		} else if ok, zd, pg, zn, _, T := is_prefixed_z_p_zz(args); ok && T != "b" {
+			return assem_prefixed_z_p_z(ins, args[1], zd, pg, zn, T)
+		} else if ok, zd, pg, zn, f, T := is_z_p_zf(args); ok && T != "b" && mnem != "fabd" && mnem != "fmulx" {
+			if zd != zn || is_zeroing(args[1]) {
+				return assem_prefixed_z_p_z(ins, args[1], zd, pg, zn, T)
+			}
+			return assem_z_p_fimm1(ins, mnem, zd, pg, f, T)
+		}
+	case "fdup":
+		if ok, zd, f, T := is_z_f(args); ok && T != "b" && T != "" {
+			// FDUP <Zd>.<T>, #<const>
+			if ok, imm8 := getFpImm8(f); ok {
+				templ := "0	0	1	0	0	1	0	1	size	1	1	1	0	0	1	1	1	0	imm8	Zd"
+				templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
+				return assem_z_i(templ, zd, "imm8", imm8), 0, nil
+			}
+			return 0, 0, errFpImm8(ins)
+		}
+	case "fcpy":
+		if ok, zd, pg, f, T := is_z_p_f(args); ok && T != "b" && T != "" && !is_zeroing(args[1]) {
+			// FCPY <Zd>.<T>, <Pg>/M, #<const>
+			if ok, imm8 := getFpImm8(f); ok {
+				templ := "0	0	0	0	0	1	0	1	size	0	1	Pg	1	1	0	imm8	Zd"
+				templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
+				templ = strings.ReplaceAll(templ, "Pg", fmt.Sprintf("%0*s", 4, strconv.FormatUint(uint64(pg), 2)))
+				return assem_z_i(templ, zd, "imm8", imm8), 0, nil
+			}
+			return 0, 0, errFpImm8(ins)
 		}
 	case "fabs":
 		if ok, zd, pg, zn, T := is_z_p_z(args); ok && T != "b" {
@@ -1850,6 +2166,79 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
 			return assem_z_p_z(templ, zd, pg, zn), 0, nil
 		}
+	case "frecpe", "frsqrte":
+		if ok, zd, zn, T := is_z_z(args); ok && T != "b" {
+			// FRECPE <Zd>.<T>, <Zn>.<T> — reciprocal estimate
+			// FRSQRTE <Zd>.<T>, <Zn>.<T> — reciprocal square root estimate
+			templ := "0	1	1	0	0	1	0	1	size	0	0	1	1	1	op	0	0	1	1	0	0	Zn	Zd"
+			templ = strings.ReplaceAll(templ, "op", If(mnem == "frsqrte", "1", "0"))
+			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
+			return assem_z_zz(templ, zd, zn, 0), 0, nil
+		}
+	case "frecps", "frsqrts", "ftsmul":
+		if ok, zd, zn, zm, T := is_z_zz(args); ok && T != "b" {
+			// FRECPS <Zd>.<T>, <Zn>.<T>, <Zm>.<T> — reciprocal step
+			// FRSQRTS <Zd>.<T>, <Zn>.<T>, <Zm>.<T> — reciprocal square root step
+			// FTSMUL <Zd>.<T>, <Zn>.<T>, <Zm>.<T> — trigonometric starting value
+			var opc string
+			switch mnem {
+			case "frecps":
+				opc = "1	1	0"
+			case "frsqrts":
+				opc = "1	1	1"
+			case "ftsmul":
+				opc = "0	1	1"
+			}
+			templ := "0	1	1	0	0	1	0	1	size	0	Zm	0	0	0	opc	Zn	Zd"
+			templ = strings.ReplaceAll(templ, "opc", opc)
+			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
+			return assem_z_zz(templ, zd, zn, zm), 0, nil
+		}
+	case "frecpx":
+		if ok, zd, pg, zn, T := is_z_p_z(args); ok && T != "b" {
+			// FRECPX <Zd>.<T>, <Pg>/M, <Zn>.<T> — reciprocal exponent
+			templ := "0	1	1	0	0	1	0	1	size	0	0	1	1	0	0	1	0	1	Pg	Zn	Zd"
+			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
+			return assem_z_p_z(templ, zd, pg, zn), 0, nil
+		}
+	case "flogb":
+		if ok, zd, pg, zn, T := is_z_p_z(args); ok && T != "b" {
+			// FLOGB <Zd>.<T>, <Pg>/M, <Zn>.<T> — base 2 logarithm as integer
+			templ := "0	1	1	0	0	1	0	1	0	0	0	1	1	size	0	1	0	1	Pg	Zn	Zd"
+			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
+			return assem_z_p_z(templ, zd, pg, zn), 0, nil
+		}
+	case "fscale":
+		if ok, zdn, pg, zm, T := is_z_p_zz(args); ok && !is_zeroing(args[1]) && T != "b" {
+			// FSCALE <Zdn>.<T>, <Pg>/M, <Zdn>.<T>, <Zm>.<T>
+			templ := "0	1	1	0	0	1	0	1	size	0	0	1	0	0	1	1	0	0	Pg	Zm	Zdn"
+			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
+			return assem_z2_p_z(templ, zdn, pg, zm), 0, nil
+		} else if ok, zd, pg, zn, _, T := is_prefixed_z_p_zz(args); ok && T != "b" {
+			return assem_prefixed_z_p_z(ins, args[1], zd, pg, zn, T)
+		}
+	case "fexpa":
+		if ok, zd, zn, T := is_z_z(args); ok && T != "b" {
+			// FEXPA <Zd>.<T>, <Zn>.<T> — exponential accelerator
+			templ := "0	0	0	0	0	1	0	0	size	1	0	0	0	0	0	1	0	1	1	1	0	Zn	Zd"
+			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
+			return assem_z_zz(templ, zd, zn, 0), 0, nil
+		}
+	case "ftmad":
+		if ok, zdn, _, zm, imm, consec, T := is_z_zzi(args); ok && !consec && T != "b" && 0 <= imm && imm <= 7 {
+			// FTMAD <Zdn>.<T>, <Zdn>.<T>, <Zm>.<T>, #<imm> — trigonometric multiply-add coefficient
+			templ := "0	1	1	0	0	1	0	1	size	0	1	0	imm3	1	0	0	0	0	0	Zm	Zdn"
+			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
+			templ = strings.ReplaceAll(templ, "imm3", fmt.Sprintf("%0*s", 3, strconv.FormatUint(uint64(imm), 2)))
+			return assem_z_zzi(templ, zdn, zm), 0, nil
+		}
+	case "ftssel":
+		if ok, zd, zn, zm, T := is_z_zz(args); ok && T != "b" {
+			// FTSSEL <Zd>.<T>, <Zn>.<T>, <Zm>.<T> — trigonometric select coefficient
+			templ := "0	0	0	0	0	1	0	0	size	1	Zm	1	0	1	1	0	0	Zn	Zd"
+			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
+			return assem_z_zz(templ, zd, zn, zm), 0, nil
+		}
 	case "frintn":
 		if ok, zd, pg, zn, T := is_z_p_z(args); ok && T != "b" {
 			// FRINTN <Zd>.<T>, <Pg>/M, <Zn>.<T> — round to nearest, ties to even
@@ -1878,6 +2267,20 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
 			return assem_z_p_z(templ, zd, pg, zn), 0, nil
 		}
+	case "frintx":
+		if ok, zd, pg, zn, T := is_z_p_z(args); ok && T != "b" {
+			// FRINTX <Zd>.<T>, <Pg>/M, <Zn>.<T> — current mode, signalling inexact
+			templ := "0	1	1	0	0	1	0	1	size	0	0	0	1	1	0	1	0	1	Pg	Zn	Zd"
+			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
+			return assem_z_p_z(templ, zd, pg, zn), 0, nil
+		}
+	case "frinti":
+		if ok, zd, pg, zn, T := is_z_p_z(args); ok && T != "b" {
+			// FRINTI <Zd>.<T>, <Pg>/M, <Zn>.<T> — current mode
+			templ := "0	1	1	0	0	1	0	1	size	0	0	0	1	1	1	1	0	1	Pg	Zn	Zd"
+			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
+			return assem_z_p_z(templ, zd, pg, zn), 0, nil
+		}
 	case "frintp":
 		if ok, zd, pg, zn, T := is_z_p_z(args); ok && T != "b" {
 			// FRINTP <Zd>.<T>, <Pg>/M, <Zn>.<T> — round towards plus infinity
@@ -1885,12 +2288,27 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
 			return assem_z_p_z(templ, zd, pg, zn), 0, nil
 		}
-	case "fmad":
-		if ok, zda, pg, zn, zm, T := is_z_p_zz2(args); ok && T != "b" {
-			// FMAD <Zdn>.<T>, <Pg>/M, <Zm>.<T>, <Za>.<T>
-			templ := "0	1	1	0	0	1	0	1	size	1	Zm	1	0	0	Pg	Zn	Zda"
+	case "fmad", "fmsb", "fnmad", "fnmsb":
+		if ok, zda, pg, zn, zm, T := is_z_p_zz2(args); ok && T != "b" && !is_zeroing(args[1]) {
+			// FMAD/FMSB/FNMAD/FNMSB <Zdn>.<T>, <Pg>/M, <Zm>.<T>, <Za>.<T>
+			var op string
+			switch mnem {
+			case "fmad":
+				op = "1	0	0"
+			case "fmsb":
+				op = "1	0	1"
+			case "fnmad":
+				op = "1	1	0"
+			case "fnmsb":
+				op = "1	1	1"
+			}
+			templ := "0	1	1	0	0	1	0	1	size	1	Zm	op	Pg	Zn	Zda"
+			templ = strings.ReplaceAll(templ, "op", op)
 			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
 			return assem_z_p_zz(templ, zda, pg, zn, zm), 0, nil
+		} else if ok && T != "b" && zn != zda && zm != zda {
+			// zeroing predication is handled via MOVPRFX
+			return assem_prefixed_z_p_z(ins, args[1], zda, pg, zda, T)
 		}
 	case "cntp":
 		// CNTP <Xd>, <Pg>, <Pn>.<T>
@@ -1937,6 +2355,52 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 			}
 			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
 			return assem_p_p_zz(templ, pd, pg, zn, zm), 0, nil
+		} else if ok, pd, pg, zn, T := is_p_p_z0(args); ok && T != "b" {
+			// FCM<cc> <Pd>.<T>, <Pg>/Z, <Zn>.<T>, #0.0: 01100101 size 0100 eq lt 001 Pg Zn ne Pd
+			var cc string
+			switch mnem {
+			case "fcmge":
+				cc = "0	0	0"
+			case "fcmgt":
+				cc = "0	0	1"
+			case "fcmlt":
+				cc = "0	1	0"
+			case "fcmle":
+				cc = "0	1	1"
+			case "fcmeq":
+				cc = "1	0	0"
+			case "fcmne":
+				cc = "1	1	0"
+			}
+			templ := "0	1	1	0	0	1	0	1	size	0	1	0	0	eq	lt	0	0	1	Pg	Zn	ne	Pd"
+			templ = strings.ReplaceAll(templ, "eq	lt", cc[:3])
+			templ = strings.ReplaceAll(templ, "ne", cc[4:])
+			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
+			return assem_p_p_zi(templ, pd, pg, zn, "", 0), 0, nil
+		}
+	case "facge", "facgt", "facle", "faclt", "fcmuo":
+		if ok, pd, pg, zn, zm, T := is_p_p_zz(args); ok && T != "b" {
+			// FACGE/FACGT: 01100101 size 0 Zm 11 ac Pg Zn 1 Pd — absolute compare
+			// FCMUO: 01100101 size 0 Zm 110 Pg Zn 0 Pd — unordered compare
+			var templ string
+			switch mnem {
+			case "facge":
+				templ = "0	1	1	0	0	1	0	1	size	0	Zm	1	1	0	Pg	Zn	1	Pd"
+			case "facgt":
+				templ = "0	1	1	0	0	1	0	1	size	0	Zm	1	1	1	Pg	Zn	1	Pd"
+			case "facle":
+				// FACLE is alias for FACGE with swapped operands
+				templ = "0	1	1	0	0	1	0	1	size	0	Zm	1	1	0	Pg	Zn	1	Pd"
+				zn, zm = zm, zn
+			case "faclt":
+				// FACLT is alias for FACGT with swapped operands
+				templ = "0	1	1	0	0	1	0	1	size	0	Zm	1	1	1	Pg	Zn	1	Pd"
+				zn, zm = zm, zn
+			case "fcmuo":
+				templ = "0	1	1	0	0	1	0	1	size	0	Zm	1	1	0	Pg	Zn	0	Pd"
+			}
+			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
+			return assem_p_p_zz(templ, pd, pg, zn, zm), 0, nil
 		}
 	case "fmul":
 		if ok, zd, zn, zm, T := is_z_zz(args); ok {
@@ -1952,6 +2416,11 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 			return assem_z2_p_z(templ, zdn, pg, zm), 0, nil
 		} else if ok, zd, pg, zn, _, T := is_prefixed_z_p_zz(args); ok && T != "b" {
 			return assem_prefixed_z_p_z(ins, args[1], zd, pg, zn, T)
+		} else if ok, zd, pg, zn, f, T := is_z_p_zf(args); ok && T != "b" {
+			if zd != zn || is_zeroing(args[1]) {
+				return assem_prefixed_z_p_z(ins, args[1], zd, pg, zn, T)
+			}
+			return assem_z_p_fimm1(ins, mnem, zd, pg, f, T)
 		}
 	case "xar":
 		if ok, zd, zn, zm, imm, consec, T := is_z_zzi(args); ok && zd == zn && !consec {
@@ -1996,6 +2465,132 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 		} else if ok, zd, pg, zn, _, T := is_prefixed_z_p_zz(args); ok {
 			return assem_prefixed_z_p_z(ins, args[1], zd, pg, zn, T)
 		}
+	case "asrd", "srshr", "urshr", "sqshlu":
+		if ok, zd, pg, zn, imm, T := is_z_p_zimm(args); ok && T != "" {
+			// ASRD <Zdn>.<T>, <Pg>/M, <Zdn>.<T>, #<const> — arithmetic shift right for divide
+			// SRSHR/URSHR <Zdn>.<T>, <Pg>/M, <Zdn>.<T>, #<const> — rounding shift right
+			// SQSHLU <Zdn>.<T>, <Pg>/M, <Zdn>.<T>, #<const> — saturating shift left unsigned
+			var opc string
+			switch mnem {
+			case "asrd":
+				opc = "0	1	0	0"
+			case "srshr":
+				opc = "1	1	0	0"
+			case "urshr":
+				opc = "1	1	0	1"
+			case "sqshlu":
+				opc = "1	1	1	1"
+			}
+			right := mnem != "sqshlu"
+			if right && 1 <= imm && imm <= elementSize(T) || !right && 0 <= imm && imm < elementSize(T) {
+				if zd != zn || is_zeroing(args[1]) {
+					return assem_prefixed_z_p_z(ins, args[1], zd, pg, zn, T)
+				}
+				imm3, tsz := computeShiftSpecifier(uint(imm), right, T)
+				templ := "0	0	0	0	0	1	0	0	tszh	0	0	opc	1	0	0	Pg	tszl	imm3	Zdn"
+				templ = strings.ReplaceAll(templ, "opc", opc)
+				templ = strings.ReplaceAll(templ, "tszh", tsz[:2])
+				templ = strings.ReplaceAll(templ, "tszl", tsz[2:])
+				return assem_z_p_zi(templ, zd, pg, "imm3", imm3), 0, nil
+			}
+		}
+	case "srshl", "urshl", "srshlr", "urshlr", "sqshl", "uqshl", "sqrshl", "uqrshl", "sqshlr", "uqshlr", "sqrshlr", "uqrshlr":
+		if ok, zdn, pg, zm, T := is_z_p_zz(args); ok && !is_zeroing(args[1]) {
+			// <Zdn>.<T>, <Pg>/M, <Zdn>.<T>, <Zm>.<T>: 01000100 size 00 Q R N U 100 Pg Zm Zdn
+			// (Q: saturating, R: reversed operands, N: rounding, U: unsigned)
+			var opc string
+			switch mnem {
+			case "srshl":
+				opc = "0	0	1	0"
+			case "urshl":
+				opc = "0	0	1	1"
+			case "srshlr":
+				opc = "0	1	1	0"
+			case "urshlr":
+				opc = "0	1	1	1"
+			case "sqshl":
+				opc = "1	0	0	0"
+			case "uqshl":
+				opc = "1	0	0	1"
+			case "sqrshl":
+				opc = "1	0	1	0"
+			case "uqrshl":
+				opc = "1	0	1	1"
+			case "sqshlr":
+				opc = "1	1	0	0"
+			case "uqshlr":
+				opc = "1	1	0	1"
+			case "sqrshlr":
+				opc = "1	1	1	0"
+			case "uqrshlr":
+				opc = "1	1	1	1"
+			}
+			templ := "0	1	0	0	0	1	0	0	size	0	0	opc	1	0	0	Pg	Zm	Zdn"
+			templ = strings.ReplaceAll(templ, "opc", opc)
+			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
+			return assem_z2_p_z(templ, zdn, pg, zm), 0, nil
+		} else if ok, zd, pg, zn, _, T := is_prefixed_z_p_zz(args); ok {
+			return assem_prefixed_z_p_z(ins, args[1], zd, pg, zn, T)
+		} else if ok, zd, pg, zn, imm, T := is_z_p_zimm(args); ok && (mnem == "sqshl" || mnem == "uqshl") && T != "" && 0 <= imm && imm < elementSize(T) {
+			// SQSHL/UQSHL <Zdn>.<T>, <Pg>/M, <Zdn>.<T>, #<const>
+			if zd != zn || is_zeroing(args[1]) {
+				return assem_prefixed_z_p_z(ins, args[1], zd, pg, zn, T)
+			}
+			imm3, tsz := computeShiftSpecifier(uint(imm), false, T)
+			templ := "0	0	0	0	0	1	0	0	tszh	0	0	0	1	1	U	1	0	0	Pg	tszl	imm3	Zdn"
+			templ = strings.ReplaceAll(templ, "U", If(mnem == "uqshl", "1", "0"))
+			templ = strings.ReplaceAll(templ, "tszh", tsz[:2])
+			templ = strings.ReplaceAll(templ, "tszl", tsz[2:])
+			return assem_z_p_zi(templ, zd, pg, "imm3", imm3), 0, nil
+		}
+	case "ssra", "usra", "srsra", "ursra", "sri", "sli":
+		if ok, zd, zn, imm, T := is_z_zimm(args); ok && T != "" {
+			// SSRA/USRA/SRSRA/URSRA <Zda>.<T>, <Zn>.<T>, #<const> — shift right and accumulate
+			// SRI/SLI <Zd>.<T>, <Zn>.<T>, #<const> — shift right/left and insert
+			var op string
+			switch mnem {
+			case "ssra":
+				op = "1	0	0	0"
+			case "usra":
+				op = "1	0	0	1"
+			case "srsra":
+				op = "1	0	1	0"
+			case "ursra":
+				op = "1	0	1	1"
+			case "sri":
+				op = "1	1	0	0"
+			case "sli":
+				op = "1	1	0	1"
+			}
+			right := mnem != "sli"
+			if right && 1 <= imm && imm <= elementSize(T) || !right && 0 <= imm && imm < elementSize(T) {
+				imm3, tsz := computeShiftSpecifier(uint(imm), right, T)
+				templ := "0	1	0	0	0	1	0	1	tszh	0	tszl	imm3	1	1	op	Zn	Zd"
+				templ = strings.ReplaceAll(templ, "op", op)
+				templ = strings.ReplaceAll(templ, "tszh", tsz[:2])
+				templ = strings.ReplaceAll(templ, "tszl", tsz[2:])
+				return assem_z_zi(templ, zd, zn, "imm3", imm3), 0, nil
+			}
+		}
+	case "sshllb", "sshllt", "ushllb", "ushllt", "sxtlb", "sxtlt", "uxtlb", "uxtlt":
+		ok, zd, zn, Td, Tn := is_z_z_tt(args)
+		imm := 0
+		if strings.HasPrefix(mnem, "sshll") || strings.HasPrefix(mnem, "ushll") {
+			ok, zd, zn, imm, Td, Tn = is_z_zimm_tt(args)
+		}
+		// SXTLB <Zd>.<T>, <Zn>.<Tb>
+		// is equivalent to
+		// SSHLLB <Zd>.<T>, <Zn>.<Tb>, #0
+		if ok && Tn != "" && elementSize(Td) == 2*elementSize(Tn) && 0 <= imm && imm < elementSize(Tn) {
+			// SSHLLB/SSHLLT/USHLLB/USHLLT <Zd>.<T>, <Zn>.<Tb>, #<const>: 01000101 0 tszh 0 tszl imm3 1010 U T Zn Zd
+			imm3, tsz := computeShiftSpecifier(uint(imm), false, Tn)
+			templ := "0	1	0	0	0	1	0	1	0	tszh	0	tszl	imm3	1	0	1	0	U	T	Zn	Zd"
+			templ = strings.ReplaceAll(templ, "U", If(mnem[0] == 'u', "1", "0"))
+			templ = strings.ReplaceAll(templ, "T", If(strings.HasSuffix(mnem, "t"), "1", "0"))
+			templ = strings.ReplaceAll(templ, "tszh", tsz[1:2])
+			templ = strings.ReplaceAll(templ, "tszl", tsz[2:])
+			return assem_z_zi(templ, zd, zn, "imm3", imm3), 0, nil
+		}
 	case "sbfm": // Signed Bitfield Move
 		// use preferred assembly, either one of: asr (immediate), sbfiz, sbfx, sxtb, sxth, or sxtw.
 	case "ubfm": // Unsigned Bitfield Move
@@ -2038,8 +2633,31 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 				return assem_r_ri(templ, rd, rn, sf, "immr", int(immr), 0), 0, nil
 			}
 		}
-	case "sxtb", "uxtb", "sxth", "uxth", "sxtw": // Sign/Unsigned Extend Byte/Halfword/Word
-		if ok, rd, rn, shift, imm, sf := is_r_r(args); ok && shift == 0 && imm == 0 {
+	case "sxtb", "uxtb", "sxth", "uxth", "sxtw", "uxtw": // Sign/Unsigned Extend Byte/Halfword/Word
+		if ok, zd, pg, zn, T := is_z_p_z(args); ok && !is_zeroing(args[1]) {
+			// SXTB/UXTB/SXTH/UXTH/SXTW/UXTW <Zd>.<T>, <Pg>/M, <Zn>.<T>
+			var opc string
+			switch mnem {
+			case "sxtb":
+				opc = If(T != "b", "0	0	0", "")
+			case "uxtb":
+				opc = If(T != "b", "0	0	1", "")
+			case "sxth":
+				opc = If(T == "s" || T == "d", "0	1	0", "")
+			case "uxth":
+				opc = If(T == "s" || T == "d", "0	1	1", "")
+			case "sxtw":
+				opc = If(T == "d", "1	0	0", "")
+			case "uxtw":
+				opc = If(T == "d", "1	0	1", "")
+			}
+			if opc != "" {
+				templ := "0	0	0	0	0	1	0	0	size	0	1	0	opc	1	0	1	Pg	Zn	Zd"
+				templ = strings.ReplaceAll(templ, "opc", opc)
+				templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
+				return assem_z_p_z(templ, zd, pg, zn), 0, nil
+			}
+		} else if ok, rd, rn, shift, imm, sf := is_r_r(args); ok && shift == 0 && imm == 0 && mnem != "uxtw" {
 			// SXTB <Xd>, <Wn>
 			// is equivalent to
 			// SBFM <Xd>, <Xn>, #0, #7
@@ -2132,18 +2750,33 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 				opc, sf = "11", "000"
 			case "hh":
 				opc, sf = "01", "001"
-			}
-			if opc != "" {
-				templ := "0	1	1	0	0	1	0	1	opc	0	1	sf	U	1	0	1	Pg	Zn	Zd"
-				templ = strings.ReplaceAll(templ, "opc", opc)
-				templ = strings.ReplaceAll(templ, "sf", sf)
+			case "hs":
+				opc, sf = "01", "010"
+			case "hd":
+				opc, sf = "01", "011"
+			default:
+				return 0, 0, errSizePair(mnem, "h/h, h/s, h/d, s/s, d/s, s/d, d/d", ins)
+			}
+			templ := "0	1	1	0	0	1	0	1	opc	0	1	sf	U	1	0	1	Pg	Zn	Zd"
+			templ = strings.ReplaceAll(templ, "opc", opc)
+			templ = strings.ReplaceAll(templ, "sf", sf)
+			templ = strings.ReplaceAll(templ, "U", If(mnem == "ucvtf", "1", "0"))
+			return assem_z_p_z(templ, zd, pg, zn), 0, nil
+		} else if ok, vd, rn, sf := is_v_r(args); ok {
+			// SCVTF <Hd|Sd|Dd>, <Wn|Xn> (scalar)
+			if ftype := getFpType(args[0]); ftype != "" {
+				templ := "sf	0	0	1	1	1	1	0	ftype	1	0	0	0	1	U	0	0	0	0	0	0	Rn	Rd"
+				templ = strings.ReplaceAll(templ, "ftype", ftype)
 				templ = strings.ReplaceAll(templ, "U", If(mnem == "ucvtf", "1", "0"))
-				return assem_z_p_z(templ, zd, pg, zn), 0, nil
+				return assem_r_ri(templ, vd, rn, sf, "", 0, 0), 0, nil
 			}
 		}
-	case "fmla", "fmls", "fnmla":
+	case "fmla", "fmls", "fnmla", "fnmls":
 		if ok, zda, pg, zn, zm, T := is_z_p_zz2(args); ok {
-			if T != "b" {
+			if T != "b" && is_zeroing(args[1]) && zn != zda && zm != zda {
+				// zeroing predication is handled via MOVPRFX
+				return assem_prefixed_z_p_z(ins, args[1], zda, pg, zda, T)
+			} else if T != "b" && !is_zeroing(args[1]) {
 				var op string
 				switch mnem {
 				case "fmla":
@@ -2152,12 +2785,35 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 					op = "0	0	1"
 				case "fnmla":
 					op = "0	1	0"
+				case "fnmls":
+					op = "0	1	1"
 				}
 				templ := "0	1	1	0	0	1	0	1	size	1	Zm	op	Pg	Zn	Zda"
 				templ = strings.ReplaceAll(templ, "op", op)
 				templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
 				return assem_z_p_zz(templ, zda, pg, zn, zm), 0, nil
 			}
+		} else if ok, Tza, rv, offs, offs2, vgx, zn, _, T, zm := is_za_zz(args); ok && mnem == "fmla" && Tza == T && (T == "s" || T == "d") && vgx > 1 && zm < 16 && offs == offs2 && offs <= 7 {
+			// FMLA ZA.T[Wv, offs, VGxN], { Zn1.T-ZnN.T }, Zm.T (multiple and single vector)
+			return assem_za(sme2ZaTempl(false, vgx, If(T == "d", "1", "0"), "1	1	0", "0	0"), rv, offs, zn, zm), 0, nil
+		} else if ok, Tza, rv, offs, offs2, vgx, zn, zm, nn, T := is_za_zzn(args); ok && mnem == "fmla" && Tza == T && (T == "s" || T == "d") && offs == offs2 && offs <= 7 {
+			// FMLA ZA.T[Wv, offs, VGxN], { Zn1.T-ZnN.T }, { Zm1.T-ZmN.T } (multiple vectors)
+			return assem_za(sme2ZaTempl(true, vgx, If(T == "d", "1", "0"), "1	1	0", "0	0"), rv, offs, zn/nn, zm/nn), 0, nil
+		} else if ok, Tza, rv, offs, offs2, vgx, zn, nn, T, zm, index := is_za_zzi(args); ok && mnem == "fmla" && Tza == T && zm < 16 && offs == offs2 && offs <= 7 {
+			// FMLA ZA.T[Wv, offs, VGxN], { Zn1.T-ZnN.T }, Zm.T[index] (multiple and indexed vector)
+			var templ string
+			if T == "s" && 0 <= index && index <= 3 {
+				templ = "1	1	0	0	0	0	0	1	0	1	0	1	Zm4	vg	Rv	0	i2	Zn	0	0	0	off3"
+				templ = strings.ReplaceAll(templ, "i2", fmt.Sprintf("%0*s", 2, strconv.FormatUint(uint64(index), 2)))
+			} else if T == "d" && 0 <= index && index <= 1 {
+				templ = "1	1	0	0	0	0	0	1	1	1	0	1	Zm4	vg	Rv	0	0	i1	Zn	0	0	0	off3"
+				templ = strings.ReplaceAll(templ, "i1", fmt.Sprintf("%0*s", 1, strconv.FormatUint(uint64(index), 2)))
+			}
+			if templ != "" {
+				templ = strings.ReplaceAll(templ, "vg", If(vgx == 4, "1", "0"))
+				templ = strings.ReplaceAll(templ, "Zn", If(vgx == 4, "Zn3	0", "Zn4"))
+				return assem_za(templ, rv, offs, zn/nn, zm), 0, nil
+			}
 		}
 	case "sel":
 		if ok, zd, pv, zn, zm, T := is_z_p_zz_4(args); ok {
@@ -2292,7 +2948,7 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 			}
 		}
 	case "clz":
-		if ok, rd, rn, shift, imm, sf := is_r_r(args); ok && len(args) == 2 && shift == 0 && imm == 0 {
+		if ok, rd, rn, shift, imm, sf := is_r_r(args); ok && len(args) == 2 && shift == 0 && imm == 0 && isSameWidth(args...) {
 			templ := "sf	1	0	1	1	0	1	0	1	1	0	0	0	0	0	0	0	0	0	1	0	0	Rn	Rd"
 			return assem_r_ri(templ, rd, rn, sf, "", 0, 0), 0, nil
 		} else if ok, zd, pg, zn, T := is_z_p_z(args); ok && T != "" {
@@ -2300,6 +2956,14 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
 			return assem_z_p_z(templ, zd, pg, zn), 0, nil
 		}
+	case "saba", "uaba":
+		if ok, zda, zn, zm, T := is_z_zz(args); ok {
+			// SABA/UABA <Zda>.<T>, <Zn>.<T>, <Zm>.<T> — absolute difference and accumulate
+			templ := "0	1	0	0	0	1	0	1	size	0	Zm	1	1	1	1	1	U	Zn	Zd"
+			templ = strings.ReplaceAll(templ, "U", If(mnem == "uaba", "1", "0"))
+			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
+			return assem_z_zz(templ, zda, zn, zm), 0, nil
+		}
 	case "sabd":
 		if ok, zdn, pg, zm, T := is_z_p_zz(args); !is_zeroing(args[1]) && ok {
 			templ := "0	0	0	0	0	1	0	0	size	0	0	1	1	0	0	0	0	0	Pg	Zm	Zdn"
@@ -2309,7 +2973,7 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 			return assem_prefixed_z_p_z(ins, args[1], zd, pg, zn, T)
 		}
 	case "sdiv":
-		if ok, zdn, pg, zm, T := is_z_p_zz(args); !is_zeroing(args[1]) && ok {
+		if ok, zdn, pg, zm, T := is_z_p_zz(args); ok && !is_zeroing(args[1]) {
 			if strings.ToLower(T) == "d" || strings.ToLower(T) == "s" {
 				// sdiv only defined for 64- and 32-bit
 				templ := "0	0	0	0	0	1	0	0	size	0	1	0	1	0	0	0	0	0	Pg	Zm	Zdn"
@@ -2321,7 +2985,7 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 				// sdiv only defined for 64- and 32-bit
 				return assem_prefixed_z_p_z(ins, args[1], zd, pg, zn, T)
 			}
-		} else if ok, rd, rn, rm, shift, imm, sf := is_r_rr(args); ok && shift == 0 && imm == 0 {
+		} else if ok, rd, rn, rm, shift, imm, sf := is_r_rr(args); ok && shift == 0 && imm == 0 && isSameWidth(args...) {
 			templ := "sf	0	0	1	1	0	1	0	1	1	0	Rm	0	0	0	0	1	1	Rn	Rd"
 			return assem_r_rr(templ, rd, rn, rm, sf, "", 0), 0, nil
 		}
@@ -2349,13 +3013,19 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 		} else if ok, zd, pg, zn, _, T := is_prefixed_z_p_zz(args); ok {
 			return assem_prefixed_z_p_z(ins, args[1], zd, pg, zn, T)
 		}
-	case "smulh":
-		if ok, zdn, pg, zm, T := is_z_p_zz(args); !is_zeroing(args[1]) && ok {
-			templ := "0	0	0	0	0	1	0	0	size	0	1	0	0	1	0	0	0	0	Pg	Zm	Zdn"
+	case "smulh", "umulh":
+		if ok, zdn, pg, zm, T := is_z_p_zz(args); ok && !is_zeroing(args[1]) {
+			templ := "0	0	0	0	0	1	0	0	size	0	1	0	0	1	U	0	0	0	Pg	Zm	Zdn"
+			templ = strings.ReplaceAll(templ, "U", If(mnem == "umulh", "1", "0"))
 			templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
 			return assem_z2_p_z(templ, zdn, pg, zm), 0, nil
 		} else if ok, zd, pg, zn, _, T := is_prefixed_z_p_zz(args); ok {
 			return assem_prefixed_z_p_z(ins, args[1], zd, pg, zn, T)
+		} else if ok, rd, rn, rm, shift, imm, _ := is_r_rr(args); ok && shift == 0 && imm == 0 && isSameWidth(args[0], args[1], args[2], "x0") {
+			// SMULH/UMULH <Xd>, <Xn>, <Xm> — only defined for 64-bit
+			templ := "1	0	0	1	1	0	1	1	U	1	0	Rm	0	Ra	Rn	Rd"
+			templ = strings.ReplaceAll(templ, "U", If(mnem == "umulh", "1", "0"))
+			return assem_r_rrr(templ, rd, rn, rm, 31, 1), 0, nil
 		}
 	case "sub":
 		if ok, rd, rn, rm, shift, imm, sf := is_r_rr(args); ok && 0 <= imm && imm <= 63 {
@@ -2548,6 +3218,15 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 				return assem_p_p(templ, pd, pn), 0, nil
 			}
 		}
+	case "adclb", "adclt", "sbclb", "sbclt":
+		if ok, zda, zn, zm, T := is_z_zz(args); ok && (T == "s" || T == "d") {
+			// ADCLB/ADCLT/SBCLB/SBCLT <Zda>.<T>, <Zn>.<T>, <Zm>.<T> — long add/subtract with carry (bottom/top)
+			templ := "0	1	0	0	0	1	0	1	S	sz	0	Zm	1	1	0	1	0	T	Zn	Zd"
+			templ = strings.ReplaceAll(templ, "S", If(strings.HasPrefix(mnem, "sbc"), "1", "0"))
+			templ = strings.ReplaceAll(templ, "sz", If(T == "d", "1", "0"))
+			templ = strings.ReplaceAll(templ, "T", If(strings.HasSuffix(mnem, "t"), "1", "0"))
+			return assem_z_zz(templ, zda, zn, zm), 0, nil
+		}
 	case "pmullb", "pmullt":
 		if ok, zd, zn, zm, Td, T := is_z_zz_2t(args); ok {
 			templ := "0	1	0	0	0	1	0	1	size	0	Zm	0	1	1	0	1	T	Zn	Zd"
@@ -2641,6 +3320,31 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 			templ = strings.ReplaceAll(templ, "cond", fmt.Sprintf("%0*s", 4, strconv.FormatUint(uint64(cond), 2)))
 			return assem_r_rr(templ, rd, rn, rm, sf, "", 0), 0, nil
 		}
+	case "ccmp", "ccmn":
+		if ok, rn, rm, nzcv, cond, sf := is_rr_nzcv_cond(args); ok && isSameWidth(args[0], args[1]) {
+			templ := "sf	op	1	1	1	0	1	0	0	1	0	Rm	cond	0	0	Rn	0	nzcv"
+			templ = strings.ReplaceAll(templ, "op", If(mnem == "ccmp", "1", "0"))
+			templ = strings.ReplaceAll(templ, "cond", fmt.Sprintf("%0*s", 4, strconv.FormatUint(uint64(cond), 2)))
+			templ = strings.ReplaceAll(templ, "nzcv", fmt.Sprintf("%0*s", 4, strconv.FormatUint(uint64(nzcv), 2)))
+			return assem_r_rr(templ, 0, rn, rm, sf, "", 0), 0, nil
+		} else if ok, rn, imm, nzcv, cond, sf := is_ri_nzcv_cond(args); ok && isSameWidth(args[0]) && 0 <= imm && imm <= 31 {
+			templ := "sf	op	1	1	1	0	1	0	0	1	0	imm5	cond	1	0	Rn	0	nzcv"
+			templ = strings.ReplaceAll(templ, "op", If(mnem == "ccmp", "1", "0"))
+			templ = strings.ReplaceAll(templ, "imm5", fmt.Sprintf("%0*s", 5, strconv.FormatUint(uint64(imm), 2)))
+			templ = strings.ReplaceAll(templ, "cond", fmt.Sprintf("%0*s", 4, strconv.FormatUint(uint64(cond), 2)))
+			templ = strings.ReplaceAll(templ, "nzcv", fmt.Sprintf("%0*s", 4, strconv.FormatUint(uint64(nzcv), 2)))
+			return assem_r_rr(templ, 0, rn, 0, sf, "", 0), 0, nil
+		}
+	case "crc32b", "crc32h", "crc32w", "crc32x", "crc32cb", "crc32ch", "crc32cw", "crc32cx":
+		// CRC32X <Wd>, <Wn>, <Xm> — all other sizes take a 32-bit <Wm>
+		sz := mnem[len(mnem)-1]
+		if ok, rd, rn, rm, shift, imm, _ := is_r_rr(args); ok && shift == 0 && imm == 0 &&
+			isSameWidth(args[0], args[1], "w0") && isSameWidth(args[2], If(sz == 'x', "x0", "w0")) {
+			templ := "sf	0	0	1	1	0	1	0	1	1	0	Rm	0	1	0	C	sz	Rn	Rd"
+			templ = strings.ReplaceAll(templ, "C", If(mnem[5] == 'c', "1", "0"))
+			templ = strings.ReplaceAll(templ, "sz", map[byte]string{'b': "00", 'h': "01", 'w': "10", 'x': "11"}[sz])
+			return assem_r_rr(templ, rd, rn, rm, If(sz == 'x', 1, 0), "", 0), 0, nil
+		}
 	case "cinc", "cneg", "cinv":
 		if ok, rd, rn, cond, sf := is_r_r_cond(args); ok {
 			// CINC <Xd>, <Xn>, <cond>                 | CNEG <Wd>, <Wn>, <cond>
@@ -2716,11 +3420,135 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 			templ = strings.ReplaceAll(templ, "Rs", "Rm")
 			return assem_r_rr(templ, rt, rn, rs, 0, "", 0), 0, nil
 		}
+	case "ldar", "ldarb", "ldarh", "ldapr", "ldaprb", "ldaprh", "stlr", "stlrb", "stlrh",
+		"ldxr", "ldxrb", "ldxrh", "ldaxr", "ldaxrb", "ldaxrh":
+		if ok, rt, rn := is_r_b(args); ok {
+			// LDAR/LDAPR/STLR/LDXR/LDAXR <Wt|Xt>, [<Xn|SP>{, #0}]
+			var templ string
+			switch strings.TrimRight(mnem, "bh") {
+			case "ldar":
+				templ = "size	0	0	1	0	0	0	1	1	0	1	1	1	1	1	1	1	1	1	1	1	Rn	Rt"
+			case "ldapr":
+				templ = "size	1	1	1	0	0	0	1	0	1	1	1	1	1	1	1	1	0	0	0	0	Rn	Rt"
+			case "stlr":
+				templ = "size	0	0	1	0	0	0	1	0	0	1	1	1	1	1	1	1	1	1	1	1	Rn	Rt"
+			case "ldxr":
+				templ = "size	0	0	1	0	0	0	0	1	0	1	1	1	1	1	0	1	1	1	1	1	Rn	Rt"
+			case "ldaxr":
+				templ = "size	0	0	1	0	0	0	0	1	0	1	1	1	1	1	1	1	1	1	1	1	Rn	Rt"
+			}
+			if size := getAccessSize(mnem, args[0]); size != "" {
+				templ = strings.ReplaceAll(templ, "size", size)
+				templ = strings.ReplaceAll(templ, "Rt", "Rd")
+				return assem_r_rr(templ, rt, rn, 0, 0, "", 0), 0, nil
+			}
+		}
+	case "stxr", "stxrb", "stxrh", "stlxr", "stlxrb", "stlxrh":
+		if ok, rt, rs, rn := is_r_r_b(args); ok && args[0][0] == 'w' {
+			// STXR/STLXR <Ws>, <Wt|Xt>, [<Xn|SP>{, #0}]
+			if rs == rt || rs == rn && rn != 31 {
+				return 0, 0, fmt.Errorf("status register must differ from the data and base registers: %s", ins)
+			}
+			templ := "size	0	0	1	0	0	0	0	0	0	Rs	o0	1	1	1	1	1	Rn	Rt"
+			if size := getAccessSize(mnem, args[1]); size != "" {
+				templ = strings.ReplaceAll(templ, "size", size)
+				templ = strings.ReplaceAll(templ, "o0", If(strings.HasPrefix(mnem, "stlxr"), "1", "0"))
+				templ = strings.ReplaceAll(templ, "Rt", "Rd")
+				templ = strings.ReplaceAll(templ, "Rs", "Rm")
+				return assem_r_rr(templ, rt, rn, rs, 0, "", 0), 0, nil
+			}
+		}
+	case "ldxp", "ldaxp":
+		if ok, rt2, rt, rn := is_r_r_b(args); ok && args[0][0] == args[1][0] {
+			// LDXP/LDAXP <Wt1|Xt1>, <Wt2|Xt2>, [<Xn|SP>{, #0}]
+			templ := "1	sf	0	0	1	0	0	0	0	1	1	1	1	1	1	1	o0	Rt2	Rn	Rt"
+			templ = strings.ReplaceAll(templ, "o0", If(mnem == "ldaxp", "1", "0"))
+			templ = strings.ReplaceAll(templ, "Rt2", fmt.Sprintf("%0*s", 5, strconv.FormatUint(uint64(rt2), 2)))
+			templ = strings.ReplaceAll(templ, "Rt", "Rd")
+			return assem_r_rr(templ, rt, rn, 0, sfBit(args[0]), "", 0), 0, nil
+		}
+	case "stxp", "stlxp":
+		if ok, rs, rt, rt2, rn := is_r_rr_b(args); ok && args[0][0] == 'w' && args[1][0] == args[2][0] {
+			// STXP/STLXP <Ws>, <Wt1|Xt1>, <Wt2|Xt2>, [<Xn|SP>{, #0}]
+			if rs == rt || rs == rt2 || rs == rn && rn != 31 {
+				return 0, 0, fmt.Errorf("status register must differ from the data and base registers: %s", ins)
+			}
+			templ := "1	sf	0	0	1	0	0	0	0	0	1	Rs	o0	Rt2	Rn	Rt"
+			templ = strings.ReplaceAll(templ, "o0", If(mnem == "stlxp", "1", "0"))
+			templ = strings.ReplaceAll(templ, "Rt2", fmt.Sprintf("%0*s", 5, strconv.FormatUint(uint64(rt2), 2)))
+			templ = strings.ReplaceAll(templ, "Rt", "Rd")
+			templ = strings.ReplaceAll(templ, "Rs", "Rm")
+			return assem_r_rr(templ, rt, rn, rs, sfBit(args[1]), "", 0), 0, nil
+		}
 	case "svc":
 		if ok, imm := is_i(args); ok && 0 <= imm && imm < 0x10000 {
 			templ := "1	1	0	1	0	1	0	0	0	0	0	imm16	0	0	0	0	1"
 			return assem_i(templ, "imm16", imm), 0, nil
 		}
+	case "hint":
+		if ok, imm := is_i(args); ok && 0 <= imm && imm < 0x80 {
+			templ := "1	1	0	1	0	1	0	1	0	0	0	0	0	0	1	1	0	0	1	0	imm7	1	1	1	1	1"
+			return assem_i(templ, "imm7", imm), 0, nil
+		}
+	case "yield", "wfe", "wfi", "sev", "sevl":
+		if len(args) == 0 {
+			// YIELD is equivalent to HINT #1, WFE to HINT #2, etc.
+			imm := map[string]int{"yield": 1, "wfe": 2, "wfi": 3, "sev": 4, "sevl": 5}[mnem]
+			templ := "1	1	0	1	0	1	0	1	0	0	0	0	0	0	1	1	0	0	1	0	imm7	1	1	1	1	1"
+			return assem_i(templ, "imm7", imm), 0, nil
+		}
+	case "bti":
+		// BTI {<targets>} is equivalent to HINT #(32 | targets<<1)
+		targets := map[string]int{"": 0, "c": 1, "j": 2, "jc": 3}
+		if target, ok := targets[strings.Join(args, "")]; ok && len(args) <= 1 {
+			templ := "1	1	0	1	0	1	0	1	0	0	0	0	0	0	1	1	0	0	1	0	imm7	1	1	1	1	1"
+			return assem_i(templ, "imm7", 32|target<<1), 0, nil
+		}
+	case "sb":
+		if len(args) == 0 {
+			templ := "1	1	0	1	0	1	0	1	0	0	0	0	0	0	1	1	0	0	1	1	0	0	0	0	1	1	1	1	1	1	1	1"
+			return assem_i(templ, "", 0), 0, nil
+		}
+	case "dmb", "dsb", "isb":
+		// ISB defaults to the full system option SY, the only named option it accepts
+		if mnem == "isb" && len(args) == 1 && args[0] != "sy" && !strings.HasPrefix(args[0], "#") {
+			return 0, 0, fmt.Errorf("isb only accepts sy or #imm as option: %s", ins)
+		}
+		if ok, option := is_barrier_option(args); ok || (mnem == "isb" && len(args) == 0) {
+			option = If(len(args) == 0, 15, option)
+			templ := "1	1	0	1	0	1	0	1	0	0	0	0	0	0	1	1	0	0	1	1	CRm	1	opc	1	1	1	1	1"
+			templ = strings.ReplaceAll(templ, "opc", map[string]string{"dsb": "0	0", "dmb": "0	1", "isb": "1	0"}[mnem])
+			return assem_i(templ, "CRm", option), 0, nil
+		}
+	case "mrs":
+		if ok, rt, sysreg := is_r_sysreg(args); ok && args[0][0] == 'x' {
+			templ := "1	1	0	1	0	1	0	1	0	0	1	1	sysreg	Rd"
+			return assem_r_i(templ, rt, "sysreg", sysreg), 0, nil
+		}
+	case "msr":
+		if len(args) == 2 {
+			if ok, rt, sysreg := is_r_sysreg([]string{args[1], args[0]}); ok && args[1][0] == 'x' {
+				templ := "1	1	0	1	0	1	0	1	0	0	0	1	sysreg	Rd"
+				return assem_r_i(templ, rt, "sysreg", sysreg), 0, nil
+			}
+		}
+	case "dc":
+		// DC <dc_op>, <Xt> is equivalent to SYS #<op1>, C7, <Cm>, #<op2>, <Xt>
+		// (op0 is fixed at 0b01, so the sysreg field is 1:op1:CRn:CRm:op2)
+		ops := map[string]int{
+			"ivac":  0b1_000_0111_0110_001,
+			"zva":   0b1_011_0111_0100_001,
+			"cvac":  0b1_011_0111_1010_001,
+			"cvau":  0b1_011_0111_1011_001,
+			"cvap":  0b1_011_0111_1100_001,
+			"civac": 0b1_011_0111_1110_001,
+		}
+		if len(args) == 2 && getR(args[1]) != -1 && args[1][0] == 'x' {
+			if op, ok := ops[args[0]]; ok {
+				templ := "1	1	0	1	0	1	0	1	0	0	0	0	sysreg	Rd"
+				return assem_r_i(templ, getR(args[1]), "sysreg", op), 0, nil
+			}
+		}
 	case "aesd", "aese":
 		if ok, zd, zn, zm, T := is_z_zz(args); ok && strings.ToLower(T) == "b" && zd == zn {
 			templ := "0	1	0	0	0	1	0	1	0	0	1	0	0	0	1	0	1	1	1	0	0	U	Zm	Zdn"
@@ -2729,6 +3557,23 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 			templ = strings.ReplaceAll(templ, "Zm", "Zn")
 			return assem_z_z(templ, zd, zm), 0, nil
 		}
+	case "sm4e":
+		if ok, zd, zn, zm, T := is_z_zz(args); ok && strings.ToLower(T) == "s" && zd == zn {
+			templ := "0	1	0	0	0	1	0	1	0	0	1	0	0	0	1	1	1	1	1	0	0	0	Zm	Zdn"
+			templ = strings.ReplaceAll(templ, "Zdn", "Zd")
+			templ = strings.ReplaceAll(templ, "Zm", "Zn")
+			return assem_z_z(templ, zd, zm), 0, nil
+		}
+	case "sm4ekey":
+		if ok, zd, zn, zm, T := is_z_zz(args); ok && strings.ToLower(T) == "s" {
+			templ := "0	1	0	0	0	1	0	1	0	0	1	Zm	1	1	1	1	0	0	Zn	Zd"
+			return assem_z_zz(templ, zd, zn, zm), 0, nil
+		}
+	case "rax1":
+		if ok, zd, zn, zm, T := is_z_zz(args); ok && strings.ToLower(T) == "d" {
+			templ := "0	1	0	0	0	1	0	1	0	0	1	Zm	1	1	1	1	0	1	Zn	Zd"
+			return assem_z_zz(templ, zd, zn, zm), 0, nil
+		}
 	case "aesimc", "aesmc":
 		if ok, zd, zn, T := is_z_z(args); ok && strings.ToLower(T) == "b" && zd == zn {
 			templ := "0	1	0	0	0	1	0	1	0	0	1	0	0	0	0	0	1	1	1	0	0	U	0	0	0	0	0	Zdn"
@@ -2744,11 +3589,616 @@ func Assemble(ins string) (opcode, opcode2 uint32, err error) {
 			templ = strings.ReplaceAll(templ, "imm8h", fmt.Sprintf("%0*s", 5, strconv.FormatUint(uint64((imm>>3)&31), 2)))
 			return assem_z_zzi(templ, zd, If(consec, zn, zm)), 0, nil
 		}
+	default:
+		if ok, opc, ar, st := getAtomicMnemonic(mnem); ok {
+			// LD<OP>{A}{L}{B|H} <Ws>, <Wt>, [<Xn|SP>] — atomic memory operations (LSE)
+			// ST<OP>{L}{B|H} <Ws>, [<Xn|SP>]
+			// is equivalent to
+			// LD<OP>{L}{B|H} <Ws>, WZR, [<Xn|SP>]
+			rs, rt, rn := -1, 31, -1
+			if st && len(args) >= 2 {
+				rs = getR(args[0])
+				if rn_, imm := getMemAddrImm(args[1:]); imm == 0 {
+					rn = rn_
+				}
+			} else if ok, rt_, rs_, rn_ := is_r_r_b(args); ok && !st && args[0][0] == args[1][0] {
+				rs, rt, rn = rs_, rt_, rn_
+			}
+			if rs == -1 || rn == -1 {
+				break
+			}
+			if size := getAccessSize(mnem, args[0]); size != "" {
+				templ := "size	1	1	1	0	0	0	ar	1	Rs	opc	0	0	Rn	Rt"
+				templ = strings.ReplaceAll(templ, "size", size)
+				templ = strings.ReplaceAll(templ, "ar", ar)
+				templ = strings.ReplaceAll(templ, "opc", opc)
+				templ = strings.ReplaceAll(templ, "Rt", "Rd")
+				templ = strings.ReplaceAll(templ, "Rs", "Rm")
+				return assem_r_rr(templ, rt, rn, rs, 0, "", 0), 0, nil
+			}
+		}
 	}
 
 	return 0, 0, fmt.Errorf("unhandled instruction: %s", ins)
 }
 
+// assembleNeon assembles the Advanced SIMD (NEON) instructions, i.e. those that
+// operate on vector registers with an arrangement specifier (`v0.16b`, `v1.4s`)
+// or on a vector element (`v2.s[1]`).
+func assembleNeon(ins, mnem string, args []string) (opcode, opcode2 uint32, err error) {
+	switch mnem {
+	case "add", "sub", "mul", "mla", "mls", "cmeq", "cmtst", "cmgt", "cmge", "cmhi", "cmhs", "cmle", "cmlt",
+		"smax", "umax", "smin", "umin", "addp", "smaxp", "umaxp", "sminp", "uminp", "sabd", "uabd",
+		"sqadd", "uqadd", "sqsub", "uqsub", "sshl", "ushl", "shadd", "uhadd", "srhadd", "urhadd":
+		// U and opcode fields; 2D is only defined for the first two rows
+		threeSame := map[string]string{
+			"add": "0	10000", "sub": "1	10000", "cmeq": "1	10001", "cmtst": "0	10001", "cmgt": "0	00110",
+			"cmge": "0	00111", "cmhi": "1	00110", "cmhs": "1	00111", "addp": "0	10111", "sqadd": "0	00001",
+			"uqadd": "1	00001", "sqsub": "0	00101", "uqsub": "1	00101", "sshl": "0	01000", "ushl": "1	01000",
+
+			"mul": "0	10011", "mla": "0	10010", "mls": "1	10010", "smax": "0	01100", "umax": "1	01100",
+			"smin": "0	01101", "umin": "1	01101", "smaxp": "0	10100", "umaxp": "1	10100", "sminp": "0	10101",
+			"uminp": "1	10101", "sabd": "0	01110", "uabd": "1	01110", "shadd": "0	00000", "uhadd": "1	00000",
+			"srhadd": "0	00010", "urhadd": "1	00010",
+		}
+		// U and opcode fields for the compare against zero forms
+		cmpZero := map[string]string{
+			"cmgt": "0	01000", "cmeq": "0	01001", "cmlt": "0	01010", "cmge": "1	01000", "cmle": "1	01001",
+		}
+		if ok, vd, vn, vm, T := is_v_vv(args); ok && threeSame[mnem] != "" {
+			no2d := strings.Contains(" mul mla mls smax umax smin umin smaxp umaxp sminp uminp sabd uabd shadd uhadd srhadd urhadd ", " "+mnem+" ")
+			if ok, size, Q := getArrangement(T); ok && (size != "11" || Q == "1" && !no2d) {
+				f := strings.Split(threeSame[mnem], "	")
+				templ := "0	Q	U	0	1	1	1	0	size	1	Rm	opcode	1	Rn	Rd"
+				templ = strings.ReplaceAll(templ, "U", f[0])
+				templ = strings.ReplaceAll(templ, "opcode", f[1])
+				return assem_v_vv(templ, size, Q, vd, vn, vm), 0, nil
+			}
+		} else if ok, vd, vn, T := is_v_v0(args); ok && cmpZero[mnem] != "" {
+			if ok, size, Q := getArrangement(T); ok && (size != "11" || Q == "1") {
+				f := strings.Split(cmpZero[mnem], "	")
+				templ := "0	Q	U	0	1	1	1	0	size	1	0	0	0	0	opcode	1	0	Rn	Rd"
+				templ = strings.ReplaceAll(templ, "U", f[0])
+				templ = strings.ReplaceAll(templ, "opcode", f[1])
+				return assem_v_vv(templ, size, Q, vd, vn, 0), 0, nil
+			}
+		} else if ok, vd, vn, vm, index, T := is_v_vv_elem(args); ok && (mnem == "mul" || mnem == "mla" || mnem == "mls") {
+			// MUL <Vd>.<T>, <Vn>.<T>, <Vm>.<Ts>[<index>] (by element, H and S only)
+			if ok, size, Q := getArrangement(T); ok && (size == "01" && vm < 16 && index < 8 || size == "10" && index < 4) {
+				templ := "0	Q	U	0	1	1	1	1	size	L	Rm	opcode	H	0	Rn	Rd"
+				templ = strings.ReplaceAll(templ, "U", If(mnem == "mul", "0", "1"))
+				templ = strings.ReplaceAll(templ, "opcode", map[string]string{"mul": "1000", "mla": "0000", "mls": "0100"}[mnem])
+				templ, vm = getElemHL(templ, size, index, vm)
+				return assem_v_vv(templ, size, Q, vd, vn, vm), 0, nil
+			}
+		}
+	case "and", "bic", "orr", "orn", "eor", "bsl", "bit", "bif", "mov", "ins":
+		if mnem == "mov" && len(args) == 2 && strings.Contains(args[1], ".") && !strings.Contains(args[1], "[") && !strings.Contains(args[0], "[") {
+			// MOV <Vd>.<T>, <Vn>.<T> is equivalent to ORR <Vd>.<T>, <Vn>.<T>, <Vn>.<T>
+			args = append(args, args[1])
+		}
+		if ok, vd, vn, vm, T := is_v_vv(args); ok && (T == "8b" || T == "16b") && mnem != "ins" {
+			opc := map[string]string{
+				"and": "0	00", "bic": "0	01", "orr": "0	10", "orn": "0	11", "mov": "0	10",
+				"eor": "1	00", "bsl": "1	01", "bit": "1	10", "bif": "1	11",
+			}[mnem]
+			templ := "0	Q	U	0	1	1	1	0	size	1	Rm	0	0	0	1	1	1	Rn	Rd"
+			templ = strings.ReplaceAll(templ, "U", opc[:1])
+			return assem_v_vv(templ, opc[2:], If(T == "16b", "1", "0"), vd, vn, vm), 0, nil
+		} else if ok, vd, index, rn, Ts := is_velem_r(args); ok && (mnem == "mov" || mnem == "ins") {
+			// MOV <Vd>.<Ts>[<index>], <R><n> is equivalent to INS (general)
+			if imm5 := getElemImm5(Ts, index); imm5 != 0 && (Ts == "d") == (args[1][0] == 'x') {
+				templ := "0	1	0	0	1	1	1	0	0	0	0	imm5	0	0	0	1	1	1	Rn	Rd"
+				templ = strings.ReplaceAll(templ, "imm5", fmt.Sprintf("%0*s", 5, strconv.FormatUint(uint64(imm5), 2)))
+				return assem_r_rr(templ, vd, rn, 0, 0, "", 0), 0, nil
+			}
+		} else if ok, vd, index, vn, index2, Ts := is_velem_velem(args); ok && (mnem == "mov" || mnem == "ins") {
+			// MOV <Vd>.<Ts>[<index1>], <Vn>.<Ts>[<index2>] is equivalent to INS (element)
+			if imm5 := getElemImm5(Ts, index); imm5 != 0 && getElemImm5(Ts, index2) != 0 {
+				templ := "0	1	1	0	1	1	1	0	0	0	0	imm5	0	imm4	1	Rn	Rd"
+				templ = strings.ReplaceAll(templ, "imm5", fmt.Sprintf("%0*s", 5, strconv.FormatUint(uint64(imm5), 2)))
+				templ = strings.ReplaceAll(templ, "imm4", fmt.Sprintf("%0*s", 4, strconv.FormatUint(uint64(index2<<strings.Index("bhsd", Ts)), 2)))
+				return assem_r_rr(templ, vd, vn, 0, 0, "", 0), 0, nil
+			}
+		} else if ok, _, _, _, Ts := is_r_velem(args); ok && mnem == "mov" && (Ts == "s" || Ts == "d") {
+			// MOV <Wd>, <Vn>.S[<index>] is equivalent to UMOV <Wd>, <Vn>.S[<index>]
+			return assembleNeon(ins, "umov", args)
+		}
+	case "umov", "smov":
+		if ok, rd, vn, index, Ts := is_r_velem(args); ok {
+			// UMOV to a 64-bit register is only defined for D elements, SMOV for B, H and S elements
+			wide := args[0][0] == 'x'
+			valid := If(mnem == "umov", wide == (Ts == "d"), Ts != "d" && (wide || Ts != "s"))
+			if imm5 := getElemImm5(Ts, index); imm5 != 0 && valid {
+				templ := "0	Q	0	0	1	1	1	0	0	0	0	imm5	0	0	1	U	1	1	Rn	Rd"
+				templ = strings.ReplaceAll(templ, "Q", If(wide, "1", "0"))
+				templ = strings.ReplaceAll(templ, "U", If(mnem == "umov", "1", "0"))
+				templ = strings.ReplaceAll(templ, "imm5", fmt.Sprintf("%0*s", 5, strconv.FormatUint(uint64(imm5), 2)))
+				return assem_r_rr(templ, rd, vn, 0, 0, "", 0), 0, nil
+			}
+		}
+	case "fadd", "fsub", "fmul", "fdiv", "fmla", "fmls", "fmax", "fmin", "fmaxnm", "fminnm",
+		"fabd", "faddp", "fcmeq", "fcmge", "fcmgt":
+		// U, a and opcode fields
+		fpThreeSame := map[string]string{
+			"fadd": "0	0	11010", "fsub": "0	1	11010", "fmul": "1	0	11011", "fdiv": "1	0	11111",
+			"fmla": "0	0	11001", "fmls": "0	1	11001", "fmax": "0	0	11110", "fmin": "0	1	11110",
+			"fmaxnm": "0	0	11000", "fminnm": "0	1	11000", "fabd": "1	1	11010", "faddp": "1	0	11010",
+			"fcmeq": "0	0	11100", "fcmge": "1	0	11100", "fcmgt": "1	1	11100",
+		}
+		if ok, vd, vn, vm, T := is_v_vv(args); ok {
+			if ok, size, Q := getArrangement(T); ok && (size == "10" || size == "11" && Q == "1") {
+				f := strings.Split(fpThreeSame[mnem], "	")
+				templ := "0	Q	U	0	1	1	1	0	a	sz	1	Rm	opcode	1	Rn	Rd"
+				templ = strings.ReplaceAll(templ, "U", f[0])
+				templ = strings.ReplaceAll(templ, "a	sz", f[1]+"	"+size[1:])
+				templ = strings.ReplaceAll(templ, "opcode", f[2])
+				return assem_v_vv(templ, size, Q, vd, vn, vm), 0, nil
+			}
+		} else if ok, vd, vn, vm, index, T := is_v_vv_elem(args); ok && (mnem == "fmla" || mnem == "fmls" || mnem == "fmul") {
+			// FMLA <Vd>.<T>, <Vn>.<T>, <Vm>.<Ts>[<index>] (by element, S and D only)
+			if ok, size, Q := getArrangement(T); ok && (size == "10" && index < 4 || size == "11" && Q == "1" && index < 2) {
+				templ := "0	Q	0	0	1	1	1	1	1	sz	L	Rm	opcode	H	0	Rn	Rd"
+				templ = strings.ReplaceAll(templ, "sz", size[1:])
+				templ = strings.ReplaceAll(templ, "opcode", map[string]string{"fmla": "0001", "fmls": "0101", "fmul": "1001"}[mnem])
+				templ, vm = getElemHL(templ, size, index, vm)
+				return assem_v_vv(templ, size, Q, vd, vn, vm), 0, nil
+			}
+		}
+	case "cnt", "not", "mvn", "rbit", "rev64", "rev32", "rev16", "abs", "neg", "cls", "clz":
+		// U and opcode fields, followed by the element sizes for which it is defined
+		twoMisc := map[string][2]string{
+			"cnt": {"0	00101", "b"}, "not": {"1	00101", "b"}, "mvn": {"1	00101", "b"}, "rbit": {"1	00101", "b"},
+			"rev64": {"0	00000", "bhs"}, "rev32": {"1	00000", "bh"}, "rev16": {"0	00001", "b"},
+			"abs": {"0	01011", "bhsd"}, "neg": {"1	01011", "bhsd"}, "cls": {"0	00100", "bhs"}, "clz": {"1	00100", "bhs"},
+		}
+		if ok, vd, vn, T := is_v_v(args); ok {
+			if ok, size, Q := getArrangement(T); ok && strings.Contains(twoMisc[mnem][1], T[len(T)-1:]) && (size != "11" || Q == "1") {
+				f := strings.Split(twoMisc[mnem][0], "	")
+				templ := "0	Q	U	0	1	1	1	0	size	1	0	0	0	0	opcode	1	0	Rn	Rd"
+				templ = strings.ReplaceAll(templ, "U", f[0])
+				templ = strings.ReplaceAll(templ, "opcode", f[1])
+				// RBIT is encoded with size=01
+				return assem_v_vv(templ, If(mnem == "rbit", "01", size), Q, vd, vn, 0), 0, nil
+			}
+		}
+	case "fabs", "fneg", "fsqrt", "frintn", "frintm", "frintp", "frintz", "frinta", "frintx",
+		"fcvtzs", "fcvtzu", "scvtf", "ucvtf":
+		// U, a and opcode fields
+		fpTwoMisc := map[string]string{
+			"fabs": "0	1	01111", "fneg": "1	1	01111", "fsqrt": "1	1	11111",
+			"frintn": "0	0	11000", "frintm": "0	0	11001", "frintp": "0	1	11000", "frintz": "0	1	11001",
+			"frinta": "1	0	11000", "frintx": "1	0	11001",
+			"fcvtzs": "0	1	11011", "fcvtzu": "1	1	11011", "scvtf": "0	0	11101", "ucvtf": "1	0	11101",
+		}
+		if ok, vd, vn, T := is_v_v(args); ok {
+			if ok, size, Q := getArrangement(T); ok && (size == "10" || size == "11" && Q == "1") {
+				f := strings.Split(fpTwoMisc[mnem], "	")
+				templ := "0	Q	U	0	1	1	1	0	a	sz	1	0	0	0	0	opcode	1	0	Rn	Rd"
+				templ = strings.ReplaceAll(templ, "U", f[0])
+				templ = strings.ReplaceAll(templ, "a	sz", f[1]+"	"+size[1:])
+				templ = strings.ReplaceAll(templ, "opcode", f[2])
+				return assem_v_vv(templ, size, Q, vd, vn, 0), 0, nil
+			}
+		}
+	case "addv", "smaxv", "umaxv", "sminv", "uminv", "saddlv", "uaddlv":
+		// ADDV <V><d>, <Vn>.<T>; the long variants produce an element twice as wide
+		if ok, vd, vn, T := is_vs_v(args); ok && T != "2s" {
+			elem := strings.Index("bhsd", T[len(T)-1:]) + If(strings.HasSuffix(mnem, "lv"), 1, 0)
+			if ok, size, Q := getArrangement(T); ok && size != "11" && args[0][0] == "bhsd"[elem] {
+				f := strings.Split(map[string]string{
+					"addv": "0	11011", "smaxv": "0	01010", "umaxv": "1	01010", "sminv": "0	11010",
+					"uminv": "1	11010", "saddlv": "0	00011", "uaddlv": "1	00011",
+				}[mnem], "	")
+				templ := "0	Q	U	0	1	1	1	0	size	1	1	0	0	0	opcode	1	0	Rn	Rd"
+				templ = strings.ReplaceAll(templ, "U", f[0])
+				templ = strings.ReplaceAll(templ, "opcode", f[1])
+				return assem_v_vv(templ, size, Q, vd, vn, 0), 0, nil
+			}
+		}
+	case "fmaxv", "fminv", "fmaxnmv", "fminnmv":
+		// FMAXV <Sd>, <Vn>.4S
+		if ok, vd, vn, T := is_vs_v(args); ok && T == "4s" && args[0][0] == 's' {
+			templ := "0	1	1	0	1	1	1	0	a	0	1	1	0	0	0	opcode	1	0	Rn	Rd"
+			templ = strings.ReplaceAll(templ, "a", If(strings.HasPrefix(mnem, "fmin"), "1", "0"))
+			templ = strings.ReplaceAll(templ, "opcode", If(strings.HasSuffix(mnem, "nmv"), "01100", "01111"))
+			return assem_r_rr(templ, vd, vn, 0, 0, "", 0), 0, nil
+		}
+	case "uzp1", "trn1", "zip1", "uzp2", "trn2", "zip2":
+		if ok, vd, vn, vm, T := is_v_vv(args); ok {
+			if ok, size, Q := getArrangement(T); ok && (size != "11" || Q == "1") {
+				templ := "0	Q	0	0	1	1	1	0	size	0	Rm	0	opc	1	0	Rn	Rd"
+				templ = strings.ReplaceAll(templ, "opc", map[string]string{
+					"uzp1": "001", "trn1": "010", "zip1": "011", "uzp2": "101", "trn2": "110", "zip2": "111",
+				}[mnem])
+				return assem_v_vv(templ, size, Q, vd, vn, vm), 0, nil
+			}
+		}
+	case "ext":
+		// EXT <Vd>.<T>, <Vn>.<T>, <Vm>.<T>, #<index>
+		if len(args) == 4 {
+			if ok, vd, vn, vm, T := is_v_vv(args[:3]); ok && (T == "8b" || T == "16b") {
+				if ok, imm := getImm(args[3]); ok && 0 <= imm && imm < If(T == "16b", 16, 8) {
+					templ := "0	Q	1	0	1	1	1	0	0	0	0	Rm	0	imm4	0	Rn	Rd"
+					templ = strings.ReplaceAll(templ, "imm4", fmt.Sprintf("%0*s", 4, strconv.FormatUint(uint64(imm), 2)))
+					return assem_v_vv(templ, "", If(T == "16b", "1", "0"), vd, vn, vm), 0, nil
+				}
+			}
+		}
+	case "tbl", "tbx":
+		// TBL <Vd>.<Ta>, { <Vn>.16B, ... }, <Vm>.<Ta>
+		if len(args) >= 4 {
+			vd, Td, _ := getVT(args[0])
+			n, vn, count, Tn := getVList(args[1:])
+			if n > 0 && len(args) == n+2 && vd != -1 && Tn == "16b" && count <= 4 && (Td == "8b" || Td == "16b") {
+				if vm, Tm, _ := getVT(args[n+1]); vm != -1 && Tm == Td {
+					templ := "0	Q	0	0	1	1	1	0	0	0	0	Rm	0	len	op	0	0	Rn	Rd"
+					templ = strings.ReplaceAll(templ, "len", fmt.Sprintf("%0*s", 2, strconv.FormatUint(uint64(count-1), 2)))
+					templ = strings.ReplaceAll(templ, "op", If(mnem == "tbx", "1", "0"))
+					return assem_v_vv(templ, "", If(Td == "16b", "1", "0"), vd, vn, vm), 0, nil
+				}
+			}
+		}
+	case "dup":
+		if ok, vd, vn, index, T, Ts := is_v_velem(args); ok {
+			// DUP <Vd>.<T>, <Vn>.<Ts>[<index>]
+			if ok, size, Q := getArrangement(T); ok && (size != "11" || Q == "1") && strings.HasSuffix(T, Ts) {
+				if imm5 := getElemImm5(Ts, index); imm5 != 0 {
+					templ := "0	Q	0	0	1	1	1	0	0	0	0	imm5	0	0	0	0	0	1	Rn	Rd"
+					templ = strings.ReplaceAll(templ, "imm5", fmt.Sprintf("%0*s", 5, strconv.FormatUint(uint64(imm5), 2)))
+					return assem_v_vv(templ, size, Q, vd, vn, 0), 0, nil
+				}
+			}
+		} else if ok, vd, rn, T := is_vt_r(args); ok {
+			// DUP <Vd>.<T>, <R><n>
+			if ok, size, Q := getArrangement(T); ok && (size != "11" || Q == "1") && (size == "11") == (args[1][0] == 'x') {
+				templ := "0	Q	0	0	1	1	1	0	0	0	0	imm5	0	0	0	0	1	1	Rn	Rd"
+				templ = strings.ReplaceAll(templ, "imm5", fmt.Sprintf("%0*s", 5, strconv.FormatUint(uint64(getElemImm5(T[len(T)-1:], 0)), 2)))
+				return assem_v_vv(templ, size, Q, vd, rn, 0), 0, nil
+			}
+		}
+	case "movi":
+		// MOVI <Vd>.<T>, #<imm8> for 8B/16B, and MOVI <Vd>.2D, #<imm> with each byte either 0x00 or 0xff
+		if len(args) == 2 {
+			vd, T, _ := getVT(args[0])
+			if ok, imm := getImm(args[1]); ok && vd != -1 {
+				if (T == "8b" || T == "16b") && 0 <= imm && imm < 256 {
+					return assem_movi(vd, If(T == "16b", "1", "0"), "0", imm), 0, nil
+				} else if T == "2d" {
+					imm8 := 0
+					for i := 0; i < 8; i++ {
+						switch byte(uint64(imm) >> (8 * i)) {
+						case 0xff:
+							imm8 |= 1 << i
+						case 0x00:
+						default:
+							return 0, 0, fmt.Errorf("movi immediate for .2d must consist of 0x00 and 0xff bytes: %s", ins)
+						}
+					}
+					return assem_movi(vd, "1", "1", imm8), 0, nil
+				}
+			}
+		}
+	case "sshr", "ushr", "ssra", "usra", "srshr", "urshr", "shl", "sli", "sri":
+		// SSHR <Vd>.<T>, <Vn>.<T>, #<shift>
+		if len(args) == 3 {
+			if ok, vd, vn, T := is_v_v(args[:2]); ok {
+				ok, size, Q := getArrangement(T)
+				esize, left := 8<<strings.Index("bhsd", T[len(T)-1:]), mnem == "shl" || mnem == "sli"
+				if okShift, shift := getImm(args[2]); ok && okShift && (size != "11" || Q == "1") &&
+					(left && 0 <= shift && shift < esize || !left && 1 <= shift && shift <= esize) {
+					f := strings.Split(map[string]string{
+						"sshr": "0	00000", "ushr": "1	00000", "ssra": "0	00010", "usra": "1	00010", "srshr": "0	00100",
+						"urshr": "1	00100", "shl": "0	01010", "sli": "1	01010", "sri": "1	01000",
+					}[mnem], "	")
+					templ := "0	Q	U	0	1	1	1	1	0	immhb	opcode	1	Rn	Rd"
+					templ = strings.ReplaceAll(templ, "U", f[0])
+					templ = strings.ReplaceAll(templ, "opcode", f[1])
+					templ = strings.ReplaceAll(templ, "immhb", fmt.Sprintf("%0*s", 7, strconv.FormatUint(uint64(If(left, esize+shift, 2*esize-shift)), 2)))
+					return assem_v_vv(templ, "", Q, vd, vn, 0), 0, nil
+				}
+			}
+		}
+	case "xtn", "xtn2":
+		// XTN{2} <Vd>.<Tb>, <Vn>.<Ta>
+		if ok, vd, vn, Tb, Ta := is_v_v_long(args); ok {
+			if ok, size, Q := getArrangement(Tb); ok && size != "11" && Q == If(mnem == "xtn2", "1", "0") && Ta == widen(size) {
+				templ := "0	Q	0	0	1	1	1	0	size	1	0	0	0	0	1	0	0	1	0	1	0	Rn	Rd"
+				return assem_v_vv(templ, size, Q, vd, vn, 0), 0, nil
+			}
+		}
+	case "smull", "smull2", "umull", "umull2", "pmull", "pmull2":
+		// UMULL{2} <Vd>.<Ta>, <Vn>.<Tb>, <Vm>.<Tb>
+		if len(args) == 3 {
+			if ok, vd, vn, Ta, Tb := is_v_v_long(args[:2]); ok {
+				vm, Tm, index := getVT(args[2])
+				ok, size, Q := getArrangement(Tb)
+				// PMULL is only defined for 8- and 64-bit polynomials, SMULL/UMULL for 8- to 32-bit elements
+				poly := If(strings.HasPrefix(mnem, "pmull"), size == "00" || size == "11", size != "11")
+				if ok && poly && vm != -1 && index == -1 && Tm == Tb && Ta == widen(size) && Q == If(strings.HasSuffix(mnem, "2"), "1", "0") {
+					templ := "0	Q	U	0	1	1	1	0	size	1	Rm	opcode	0	0	Rn	Rd"
+					templ = strings.ReplaceAll(templ, "U", If(mnem[0] == 'u', "1", "0"))
+					templ = strings.ReplaceAll(templ, "opcode", If(mnem[0] == 'p', "1110", "1100"))
+					return assem_v_vv(templ, size, Q, vd, vn, vm), 0, nil
+				}
+			}
+		}
+	case "sshll", "sshll2", "ushll", "ushll2", "sxtl", "sxtl2", "uxtl", "uxtl2":
+		// SSHLL{2} <Vd>.<Ta>, <Vn>.<Tb>, #<shift>; SXTL{2} is SSHLL{2} with a shift of 0
+		shift, okShift := 0, strings.Contains(mnem, "xtl")
+		if len(args) == 3 && !okShift {
+			okShift, shift = getImm(args[2])
+			args = args[:2]
+		}
+		if ok, vd, vn, Ta, Tb := is_v_v_long(args); ok && okShift {
+			ok, size, Q := getArrangement(Tb)
+			if esize := 8 << strings.Index("bhsd", Tb[len(Tb)-1:]); ok && size != "11" && Ta == widen(size) && 0 <= shift && shift < esize && Q == If(strings.HasSuffix(mnem, "2"), "1", "0") {
+				templ := "0	Q	U	0	1	1	1	1	0	immhb	1	0	1	0	0	1	Rn	Rd"
+				templ = strings.ReplaceAll(templ, "U", If(mnem[0] == 'u', "1", "0"))
+				templ = strings.ReplaceAll(templ, "immhb", fmt.Sprintf("%0*s", 7, strconv.FormatUint(uint64(esize+shift), 2)))
+				return assem_v_vv(templ, "", Q, vd, vn, 0), 0, nil
+			}
+		}
+	case "aese", "aesd", "aesmc", "aesimc":
+		// AESE <Vd>.16B, <Vn>.16B
+		if ok, vd, vn, T := is_v_v(args); ok && T == "16b" {
+			templ := "0	1	0	0	1	1	1	0	0	0	1	0	1	0	0	0	opcode	1	0	Rn	Rd"
+			templ = strings.ReplaceAll(templ, "opcode", map[string]string{"aese": "0100", "aesd": "0101", "aesmc": "0110", "aesimc": "0111"}[mnem])
+			return assem_v_vv(templ, "", "", vd, vn, 0), 0, nil
+		}
+	case "ld1", "ld2", "ld3", "ld4", "st1", "st2", "st3", "st4", "ld1r":
+		// LD1 { <Vt>.<T>, ... }, [<Xn|SP>]{, #<imm>|<Xm>}
+		if n, vt, count, T := getVList(args); n > 0 && len(args) > n {
+			ok, size, Q := getArrangement(T)
+			nreg := int(mnem[2] - '0')
+			opcode, bytes := map[int]string{1: "0111", 2: "1010", 3: "0110", 4: "0010"}[count], 8<<If(Q == "1", 1, 0)
+			templ := "0	Q	0	0	1	1	0	0	0	L	0	0	0	0	0	0	opcode	size	Rn	Rt"
+			if mnem == "ld1r" {
+				// LD1R { <Vt>.<T> }, [<Xn|SP>] loads a single element
+				opcode, bytes = If(count == 1, "110", ""), 1<<strings.Index("bhsd", T[len(T)-1:])
+				templ = "0	Q	0	0	1	1	0	1	0	L	0	0	0	0	0	0	opcode	0	size	Rn	Rt"
+			} else if nreg > 1 {
+				opcode = If(count == nreg && T != "1d", map[int]string{2: "1000", 3: "0100", 4: "0000"}[nreg], "")
+			}
+			rn, rm, mem := -1, 0, args[n:]
+			if strings.HasPrefix(mem[0], "[") && strings.HasSuffix(mem[0], "]") {
+				rn = getR(memAddrBracketReplacer.Replace(mem[0]))
+			}
+			if len(mem) == 2 {
+				// post-index, either by the number of bytes transferred or by a register
+				templ = strings.Replace(templ, "0	L	0	0	0	0	0	0", "1	L	0	Rm", 1)
+				if okImm, imm := getImm(mem[1]); okImm && imm == bytes*count {
+					rm = 31
+				} else if rm = getR(mem[1]); rm == -1 || rm == 31 || mem[1][0] != 'x' {
+					rn = -1
+				}
+			}
+			if ok && opcode != "" && rn != -1 && len(mem) <= 2 {
+				templ = strings.ReplaceAll(templ, "opcode", opcode)
+				templ = strings.ReplaceAll(templ, "L", If(mnem[0] == 'l', "1", "0"))
+				templ = strings.ReplaceAll(templ, "Rt", "Rd")
+				return assem_v_vv(templ, size, Q, vt, rn, rm), 0, nil
+			}
+		}
+	default:
+		return 0, 0, fmt.Errorf("unsupported Advanced SIMD instruction %s: %s", mnem, ins)
+	}
+	return 0, 0, fmt.Errorf("unhandled instruction: %s", ins)
+}
+
+// assembleFpScalar assembles the scalar floating-point instructions, i.e. those
+// that operate on half-, single- or double-precision registers (`h0`, `s1`, `d2`)
+func assembleFpScalar(ins, mnem string, args []string) (opcode, opcode2 uint32, err error) {
+	switch mnem {
+	case "fmul", "fdiv", "fadd", "fsub", "fmax", "fmin", "fmaxnm", "fminnm", "fnmul":
+		opcodes := map[string]string{
+			"fmul": "0000", "fdiv": "0001", "fadd": "0010", "fsub": "0011", "fmax": "0100",
+			"fmin": "0101", "fmaxnm": "0110", "fminnm": "0111", "fnmul": "1000",
+		}
+		if ok, rd, rn, rm, ftype := is_f_ff(args); ok {
+			templ := "0	0	0	1	1	1	1	0	ftype	1	Rm	opcode	1	0	Rn	Rd"
+			templ = strings.ReplaceAll(templ, "ftype", ftype)
+			templ = strings.ReplaceAll(templ, "opcode", opcodes[mnem])
+			return assem_r_rr(templ, rd, rn, rm, 0, "", 0), 0, nil
+		}
+	case "fmadd", "fmsub", "fnmadd", "fnmsub":
+		o1o0 := map[string]string{"fmadd": "0	0", "fmsub": "0	1", "fnmadd": "1	0", "fnmsub": "1	1"}
+		if ok, rd, rn, rm, ra, ftype := is_f_fff(args); ok {
+			f := strings.Split(o1o0[mnem], "	")
+			templ := "0	0	0	1	1	1	1	1	ftype	o1	Rm	o0	Ra	Rn	Rd"
+			templ = strings.ReplaceAll(templ, "ftype", ftype)
+			templ = strings.ReplaceAll(templ, "o1", f[0])
+			templ = strings.ReplaceAll(templ, "o0", f[1])
+			return assem_r_rrr(templ, rd, rn, rm, ra, 0), 0, nil
+		}
+	case "fmov", "fabs", "fneg", "fsqrt", "frintn", "frintp", "frintm", "frintz", "frinta", "frintx", "frinti":
+		opcodes := map[string]string{
+			"fmov": "000000", "fabs": "000001", "fneg": "000010", "fsqrt": "000011", "frintn": "001000",
+			"frintp": "001001", "frintm": "001010", "frintz": "001011", "frinta": "001100", "frintx": "001110",
+			"frinti": "001111",
+		}
+		if ok, rd, rn, Td, Tn := is_f_f(args); ok {
+			if Td != Tn {
+				return 0, 0, errFpPrecision(mnem, ins)
+			}
+			templ := "0	0	0	1	1	1	1	0	ftype	1	opcode	1	0	0	0	0	Rn	Rd"
+			templ = strings.ReplaceAll(templ, "ftype", Tn)
+			templ = strings.ReplaceAll(templ, "opcode", opcodes[mnem])
+			return assem_r_rr(templ, rd, rn, 0, 0, "", 0), 0, nil
+		} else if mnem != "fmov" {
+			break
+		} else if ok, rd, rn, sf := is_v_r(args); ok && args[1] != "sp" {
+			// FMOV <Hd|Sd|Dd>, <Wn|Xn>
+			ftype := getFpType(args[0])
+			if ftype == "00" && sf == 1 || ftype == "01" && sf == 0 {
+				return 0, 0, errSizePair(mnem, "s/w, d/x, h/w or h/x", ins)
+			}
+			templ := "sf	0	0	1	1	1	1	0	ftype	1	0	0	1	1	1	0	0	0	0	0	0	Rn	Rd"
+			templ = strings.ReplaceAll(templ, "ftype", ftype)
+			return assem_r_rr(templ, rd, rn, 0, sf, "", 0), 0, nil
+		} else if ok, rd, rn, sf := is_r_v(args); ok && args[0] != "sp" && getFpType(args[1]) != "" {
+			// FMOV <Wd|Xd>, <Hn|Sn|Dn>
+			ftype := getFpType(args[1])
+			if ftype == "00" && sf == 1 || ftype == "01" && sf == 0 {
+				return 0, 0, errSizePair(mnem, "w/s, x/d, w/h or x/h", ins)
+			}
+			templ := "sf	0	0	1	1	1	1	0	ftype	1	0	0	1	1	0	0	0	0	0	0	0	Rn	Rd"
+			templ = strings.ReplaceAll(templ, "ftype", ftype)
+			return assem_r_rr(templ, rd, rn, 0, sf, "", 0), 0, nil
+		} else if ok, f := getFpImm(args[len(args)-1]); ok && len(args) == 2 && getFpType(args[0]) != "" {
+			ftype := getFpType(args[0])
+			if f == 0 && !math.Signbit(f) {
+				// FMOV <Hd|Sd|Dd>, #0.0
+				// is equivalent to
+				// FMOV <Hd|Sd|Dd>, <WZR|XZR>
+				return assembleFpScalar(ins, mnem, []string{args[0], If(ftype == "01", "xzr", "wzr")})
+			}
+			ok, imm8 := getFpImm8(f)
+			if !ok {
+				return 0, 0, errFpImm8(ins)
+			}
+			templ := "0	0	0	1	1	1	1	0	ftype	1	imm8	1	0	0	0	0	0	0	0	Rd"
+			templ = strings.ReplaceAll(templ, "ftype", ftype)
+			templ = strings.ReplaceAll(templ, "imm8", fmt.Sprintf("%0*s", 8, strconv.FormatUint(uint64(imm8), 2)))
+			return assem_r_rr(templ, getV(args[0]), 0, 0, 0, "", 0), 0, nil
+		}
+	case "fcvt":
+		if ok, rd, rn, Td, Tn := is_f_f(args); ok {
+			if Td == Tn {
+				return 0, 0, errSizePair(mnem, "h/s, h/d, s/h, s/d, d/h or d/s", ins)
+			}
+			templ := "0	0	0	1	1	1	1	0	ftype	1	0	0	0	1	opc	1	0	0	0	0	Rn	Rd"
+			templ = strings.ReplaceAll(templ, "ftype", Tn)
+			templ = strings.ReplaceAll(templ, "opc", Td)
+			return assem_r_rr(templ, rd, rn, 0, 0, "", 0), 0, nil
+		}
+	case "fcmp", "fcmpe":
+		e := If(mnem == "fcmpe", "1", "0")
+		if ok, rn, rm, Tn, Tm := is_f_f(args); ok {
+			if Tn != Tm {
+				return 0, 0, errFpPrecision(mnem, ins)
+			}
+			templ := "0	0	0	1	1	1	1	0	ftype	1	Rm	0	0	1	0	0	0	Rn	E	0	0	0	0"
+			templ = strings.ReplaceAll(templ, "ftype", Tn)
+			templ = strings.ReplaceAll(templ, "E", e)
+			return assem_r_rr(templ, 0, rn, rm, 0, "", 0), 0, nil
+		} else if ok, f := getFpImm(args[len(args)-1]); ok && len(args) == 2 && getFpType(args[0]) != "" {
+			if f != 0 || math.Signbit(f) {
+				return 0, 0, fmt.Errorf("%s can only compare against #0.0: %s", mnem, ins)
+			}
+			// FCMP{E} <Hn|Sn|Dn>, #0.0
+			templ := "0	0	0	1	1	1	1	0	ftype	1	0	0	0	0	0	0	0	1	0	0	0	Rn	E	1	0	0	0"
+			templ = strings.ReplaceAll(templ, "ftype", getFpType(args[0]))
+			templ = strings.ReplaceAll(templ, "E", e)
+			return assem_r_rr(templ, 0, getV(args[0]), 0, 0, "", 0), 0, nil
+		}
+	case "fccmp", "fccmpe":
+		if ok, rn, rm, nzcv, cond, ftype := is_ff_nzcv_cond(args); ok {
+			templ := "0	0	0	1	1	1	1	0	ftype	1	Rm	cond	0	1	Rn	E	nzcv"
+			templ = strings.ReplaceAll(templ, "ftype", ftype)
+			templ = strings.ReplaceAll(templ, "cond", fmt.Sprintf("%0*s", 4, strconv.FormatUint(uint64(cond), 2)))
+			templ = strings.ReplaceAll(templ, "E", If(mnem == "fccmpe", "1", "0"))
+			templ = strings.ReplaceAll(templ, "nzcv", fmt.Sprintf("%0*s", 4, strconv.FormatUint(uint64(nzcv), 2)))
+			return assem_r_rr(templ, 0, rn, rm, 0, "", 0), 0, nil
+		}
+	case "fcsel":
+		if ok, rd, rn, rm, cond, ftype := is_f_ff_cond(args); ok {
+			templ := "0	0	0	1	1	1	1	0	ftype	1	Rm	cond	1	1	Rn	Rd"
+			templ = strings.ReplaceAll(templ, "ftype", ftype)
+			templ = strings.ReplaceAll(templ, "cond", fmt.Sprintf("%0*s", 4, strconv.FormatUint(uint64(cond), 2)))
+			return assem_r_rr(templ, rd, rn, rm, 0, "", 0), 0, nil
+		}
+	}
+
+	for _, arg := range args[1:] {
+		if ftype := getFpType(arg); ftype != "" && ftype != getFpType(args[0]) {
+			return 0, 0, errFpPrecision(mnem, ins)
+		}
+	}
+	return 0, 0, fmt.Errorf("unhandled instruction: %s", ins)
+}
+
+// Feature returns the architecture feature that an instruction requires, based
+// on the instruction group of its encoding (for a movprfx-prefixed instruction
+// pass the opcode of the instruction itself): "sme" or "sme2" for SME, "sve",
+// "sve2", "sve2p1" or "f64mm" for scalable vector instructions, "neon" for
+// Advanced SIMD, "fp" for scalar floating-point (including loads and stores of
+// SIMD&FP registers), "lse" for atomics, "crc" for crc32 and "base" for the
+// base A64 instruction set
+func Feature(ins string, opcode uint32) string {
+	fields := strings.Fields(strings.ToLower(ins))
+	if len(fields) == 0 {
+		return ""
+	}
+	args := normalizeArgsAndBraces(fields[1:])
+
+	switch op0 := opcode >> 25 & 0xf; {
+	case op0 == 0b0000 && opcode>>31 == 1:
+		sme1 := []uint32{0x00, 0x01, 0x02, 0x03, 0x08, 0x90, 0x91, 0xd0, 0xd1} // mova, zero {za}, addha and addva
+		switch {
+		case opcode>>24 == 0xc1: // multi-vector operations on ZA array vector groups
+			return "sme2"
+		case opcode>>25 == 0b1010000 && opcode>>23&1 == 0: // multi-vector contiguous and strided loads and stores
+			return "sme2"
+		case opcode&0xffdffc1f == 0xe11f8000: // ldr/str zt0
+			return "sme2"
+		case opcode>>24 == 0xc0 && !slices.Contains(sme1, opcode>>16&0xff): // zero {zt0}, movt, luti2, luti4, ...
+			return "sme2"
+		}
+		return "sme"
+	case op0 == 0b0010:
+		quadword := len(args) > 1 && strings.HasSuffix(args[1], ".q")
+		switch {
+		case opcode&0xff20e000 == 0x4400e000: // permute vector segments (tblq, zipq1, uzpq1, ...)
+			return "sve2p1"
+		case opcode&0xff20fc00 == 0x05203400, opcode&0xffe0fc00 == 0x05202400, opcode&0xfff0fc00 == 0x05602400: // tbxq, dupq, extq
+			return "sve2p1"
+		case opcode&0xffffe000 == 0x052e8000: // revd
+			return "sve2p1"
+		case (opcode>>29 == 0b101 || opcode>>29 == 0b111) && quadword: // ld1w/ld1d/st1w/st1d {<Zt>.Q}
+			return "sve2p1"
+		case opcode&0xffe0e000 == 0x05a00000: // permute vector elements (quadwords)
+			return "f64mm"
+		case opcode>>25 == 0b0100010 && opcode&0xff80f800 != 0x44800000: // SVE2 integer groups, except for sdot/udot
+			return "sve2"
+		case opcode&0xff20f000 == 0x04206000: // integer multiply vectors (unpredicated)
+			return "sve2"
+		case opcode&0xff20f800 == 0x04203800, opcode&0xff20fc00 == 0x04203400: // bitwise ternary (eor3, bcax, bsl, ...) and xar
+			return "sve2"
+		case opcode&0xff30e000 == 0x04008000 && !slices.Contains([]uint32{0b0000, 0b0001, 0b0011, 0b0100}, opcode>>16&0xf):
+			return "sve2" // shifts by immediate other than asr, lsr, lsl and asrd
+		case opcode&0xff20f800 == 0x05202800: // tbl with two table registers and tbx
+			return "sve2"
+		case opcode&0xffe0e000 == 0x05600000: // ext (constructive)
+			return "sve2"
+		case opcode&0xff20e400 == 0x25200000: // whilege, whilegt, whilehs and whilehi
+			return "sve2"
+		case opcode&0xff3ce000 == 0x6408a000, opcode&0xffffe000 == 0x650aa000: // fcvtnt, fcvtlt, fcvtxnt and fcvtx
+			return "sve2"
+		case opcode&0xfff9e000 == 0x6518a000: // flogb
+			return "sve2"
+		}
+		return "sve"
+	case op0&0b0111 == 0b0111:
+		// data processing, scalar floating-point and Advanced SIMD
+		return If(opcode>>28&1 == 1 && opcode>>30&1 == 0, "fp", "neon")
+	case op0&0b0101 == 0b0100:
+		// loads and stores
+		if opcode>>24&0b111011 == 0b111000 && opcode>>21&1 == 1 && opcode>>10&0b11 == 0 ||
+			opcode>>23&0b1111111 == 0b0010001 && opcode>>21&1 == 1 || opcode>>23&0b1111111 == 0b0010000 && opcode>>21&1 == 1 && opcode>>30&0b11 <= 0b01 {
+			return "lse" // atomic memory operations, cas and casp
+		} else if opcode>>26&1 == 1 {
+			return If(opcode>>28&0b11 == 0 && opcode>>31 == 0, "neon", "fp") // structure loads and stores are neon
+		}
+	case op0&0b0111 == 0b0101:
+		// data processing, register
+		if opcode>>21&0b1111111111 == 0b0011010110 && opcode>>13&0b111 == 0b010 {
+			return "crc"
+		}
+	}
+	return "base"
+}
+
 func is_zeroing(predicate string) bool {
 	return strings.HasSuffix(strings.ToUpper(predicate), "/Z")
 }
@@ -2760,6 +4210,17 @@ func sfBit(r string) int {
 	return 0
 }
 
+// isSameWidth reports whether all general-purpose registers are of the same
+// width (all w or all x); the stack pointer is not accepted
+func isSameWidth(regs ...string) bool {
+	for _, r := range regs {
+		if r == "sp" || len(r) == 0 || r[0] != regs[0][0] {
+			return false
+		}
+	}
+	return true
+}
+
 func getR(r string) int {
 	if len(r) > 0 && (r[0] == 'x' || r[0] == 'w') {
 		if r[1:] == "zr" {
@@ -2788,15 +4249,208 @@ func getV(v string) int {
 	return -1
 }
 
+// getVQ is getV that also accepts the full 128-bit q register, for the
+// SIMD&FP loads and stores (the only scalar instructions that take it)
+func getVQ(v string) int {
+	if len(v) > 1 && v[0] == 'q' {
+		return getV("d" + v[1:])
+	}
+	return getV(v)
+}
+
+// getVT parses a vector register with an arrangement specifier (`v0.16b`) or a
+// vector element (`v0.s[1]`); index is -1 when there is no element index
+func getVT(reg string) (_ int, T string, index int) {
+	if r, T, found := strings.Cut(reg, "."); found && len(r) > 1 && r[0] == 'v' {
+		if num, err := strconv.ParseInt(r[1:], 10, 32); err == nil && num < 32 {
+			index = -1
+			if t, idx, found := strings.Cut(T, "["); found {
+				if inum, err := strconv.ParseInt(strings.TrimSuffix(idx, "]"), 10, 32); err == nil && strings.HasSuffix(idx, "]") {
+					T, index = t, int(inum)
+				} else {
+					return -1, "", -1
+				}
+			}
+			// an arrangement (`16b`, `2d`, `1q`) or an element size (`s`, `4b`)
+			lanes := strings.TrimRight(T, "bhsdq")
+			if len(T) != len(lanes)+1 || !strings.Contains(" 1 2 4 8 16 ", " "+lanes+" ") && lanes != "" {
+				return -1, "", -1
+			}
+			return int(num), T, index
+		}
+	}
+	return -1, "", -1
+}
+
+// getVList parses a list of consecutive vector registers, either as a range
+// `{ v0.16b-v3.16b }` or as an enumeration `{ v0.16b, v1.16b }`. Registers wrap
+// around modulo 32. It returns the number of arguments consumed (0 when there
+// is no match).
+func getVList(args []string) (n, vt, count int, T string) {
+	end := 1
+	for end < len(args) && args[end] != "}" {
+		end++
+	}
+	if len(args) == 0 || args[0] != "{" || end >= len(args) || end == 1 {
+		return 0, -1, 0, ""
+	}
+	list := args[1:end]
+	if joined := strings.Join(list, ""); strings.Contains(joined, "-") {
+		first, last, _ := strings.Cut(joined, "-")
+		v1, T1, i1 := getVT(first)
+		v2, T2, i2 := getVT(last)
+		if v1 != -1 && v2 != -1 && T1 == T2 && i1 == -1 && i2 == -1 && (v2-v1+32)%32 < 4 {
+			return end + 1, v1, (v2-v1+32)%32 + 1, T1
+		}
+		return 0, -1, 0, ""
+	}
+	for i, reg := range list {
+		v, Tv, index := getVT(reg)
+		if v == -1 || index != -1 || i > 0 && (Tv != T || v != (vt+i)%32) || i >= 4 {
+			return 0, -1, 0, ""
+		} else if i == 0 {
+			vt, T = v, Tv
+		}
+	}
+	return end + 1, vt, len(list), T
+}
+
+// getArrangement returns the size and Q fields for an arrangement specifier
+func getArrangement(T string) (ok bool, size, Q string) {
+	switch T {
+	case "8b", "16b":
+		size = "00"
+	case "4h", "8h":
+		size = "01"
+	case "2s", "4s":
+		size = "10"
+	case "1d", "2d":
+		size = "11"
+	default:
+		return false, "", ""
+	}
+	return true, size, If(T == "16b" || T == "8h" || T == "4s" || T == "2d", "1", "0")
+}
+
+// widen returns the arrangement with elements twice as wide as those of size,
+// as produced by the long (and consumed by the narrowing) instructions
+func widen(size string) string {
+	return map[string]string{"00": "8h", "01": "4s", "10": "2d", "11": "1q"}[size]
+}
+
+// getElemImm5 returns the imm5 field that encodes both the element size and
+// index for DUP/INS/UMOV, or 0 when the index is out of range
+func getElemImm5(Ts string, index int) int {
+	if size := strings.Index("bhsd", Ts); size != -1 && len(Ts) == 1 && 0 <= index && index < 16>>size {
+		return (index<<1 | 1) << size
+	}
+	return 0
+}
+
+// getElemHL fills in the H and L fields of a by-element template and returns
+// the M:Rm field: the index is H:L:M for 16-bit elements (limiting Vm to
+// v0-v15), H:L for 32-bit and H for 64-bit elements
+func getElemHL(templ, size string, index, vm int) (string, int) {
+	h, l, mrm := index&1, 0, vm
+	switch size {
+	case "01":
+		h, l, mrm = index>>2&1, index>>1&1, (index&1)<<4|vm&15
+	case "10":
+		h, l = index>>1&1, index&1
+	}
+	templ = strings.ReplaceAll(templ, "	H	", "	"+strconv.Itoa(h)+"	")
+	return strings.ReplaceAll(templ, "	L	", "	"+strconv.Itoa(l)+"	"), mrm
+}
+
+// getFpType returns the ftype field for a scalar h, s or d register
+func getFpType(v string) string {
+	if getV(v) != -1 {
+		switch v[0] {
+		case 'h':
+			return "11"
+		case 's':
+			return "00"
+		case 'd':
+			return "01"
+		}
+	}
+	return ""
+}
+
+// isFpScalar returns whether an instruction is a scalar floating-point
+// instruction, i.e. one that operates on h, s or d registers
+func isFpScalar(mnem string, args []string) bool {
+	switch mnem {
+	case "fadd", "fsub", "fmul", "fdiv", "fmax", "fmin", "fmaxnm", "fminnm", "fnmul", "fmadd", "fmsub",
+		"fnmadd", "fnmsub", "fabs", "fneg", "fsqrt", "frintn", "frintp", "frintm", "frintz", "frinta",
+		"frintx", "frinti", "fcvt", "fcmp", "fcmpe", "fccmp", "fccmpe", "fcsel":
+		return len(args) > 0 && getFpType(args[0]) != ""
+	case "fmov":
+		return len(args) == 2 && (getFpType(args[0]) != "" || getFpType(args[1]) != "")
+	}
+	return false
+}
+
+// getSysReg returns the o0:op1:CRn:CRm:op2 encoding of a system register,
+// either by name or in the generic S<op0>_<op1>_C<n>_C<m>_<op2> form
+func getSysReg(name string) (bool, int) {
+	sysregs := map[string]string{
+		"midr_el1":         "s3_0_c0_c0_0",
+		"id_aa64pfr0_el1":  "s3_0_c0_c4_0",
+		"id_aa64zfr0_el1":  "s3_0_c0_c4_4",
+		"id_aa64smfr0_el1": "s3_0_c0_c4_5",
+		"id_aa64isar0_el1": "s3_0_c0_c6_0",
+		"id_aa64isar1_el1": "s3_0_c0_c6_1",
+		"dczid_el0":        "s3_3_c0_c0_7",
+		"nzcv":             "s3_3_c4_c2_0",
+		"svcr":             "s3_3_c4_c2_2",
+		"fpcr":             "s3_3_c4_c4_0",
+		"fpsr":             "s3_3_c4_c4_1",
+		"tpidr_el0":        "s3_3_c13_c0_2",
+		"tpidrro_el0":      "s3_3_c13_c0_3",
+		"tpidr2_el0":       "s3_3_c13_c0_5",
+		"cntfrq_el0":       "s3_3_c14_c0_0",
+		"cntpct_el0":       "s3_3_c14_c0_1",
+		"cntvct_el0":       "s3_3_c14_c0_2",
+	}
+	if generic, ok := sysregs[name]; ok {
+		name = generic
+	}
+	var op0, op1, crn, crm, op2 int
+	if n, err := fmt.Sscanf(name, "s%d_%d_c%d_c%d_%d", &op0, &op1, &crn, &crm, &op2); err != nil || n != 5 {
+		return false, 0
+	} else if op0 < 2 || op0 > 3 || op1 < 0 || op1 > 7 || crn < 0 || crn > 15 || crm < 0 || crm > 15 || op2 < 0 || op2 > 7 {
+		return false, 0
+	}
+	return true, (op0&1)<<14 | op1<<11 | crn<<7 | crm<<3 | op2
+}
+
+// getFpScale returns log2 of the access size in bytes for a b, h, s, d or q register
+func getFpScale(width byte) int {
+	return strings.IndexByte("bhsdq", width)
+}
+
+// getPairIndexMode returns the addressing mode bits for a load/store pair
+func getPairIndexMode(nonTemporal, postIndex, writeBack bool) string {
+	if nonTemporal {
+		return "0	0	0"
+	} else if postIndex {
+		return "0	0	1"
+	} else if writeBack {
+		return "0	1	1"
+	}
+	return "0	1	0"
+}
+
 func getCond(cond string) int {
 	switch strings.ToLower(cond) {
 	case "eq":
 		return 0
 	case "ne":
 		return 1
-	case "cs":
+	case "cs", "hs":
 		return 2
-	case "cc":
+	case "cc", "lo":
 		return 3
 	case "mi":
 		return 4
@@ -2827,6 +4481,48 @@ func getCond(cond string) int {
 	}
 }
 
+// getAccessSize returns the size field of a load/store (exclusive) for the
+// given mnemonic and data register: b and h suffixes select byte/halfword
+// accesses (which require a W register), otherwise the register width decides
+func getAccessSize(mnem, rt string) string {
+	if strings.HasSuffix(mnem, "b") {
+		return If(rt[0] == 'w', "00", "")
+	} else if strings.HasSuffix(mnem, "h") {
+		return If(rt[0] == 'w', "01", "")
+	}
+	return If(rt[0] == 'x', "11", "10")
+}
+
+// getAtomicMnemonic decodes the LSE atomic memory operations: LD<OP>, ST<OP> and
+// SWP with their optional acquire (A), release (L) and byte/halfword (B/H) suffixes
+func getAtomicMnemonic(mnem string) (ok bool, opc, ar string, st bool) {
+	ops := map[string]string{
+		"add": "0	0	0	0", "clr": "0	0	0	1", "eor": "0	0	1	0", "set": "0	0	1	1",
+		"smax": "0	1	0	0", "smin": "0	1	0	1", "umax": "0	1	1	0", "umin": "0	1	1	1",
+	}
+	m := strings.TrimRight(mnem, "bh")
+	if len(mnem)-len(m) > 1 {
+		return
+	}
+	for _, order := range []string{"", "a", "al", "l"} {
+		if !strings.HasSuffix(m, order) {
+			continue
+		}
+		base := m[:len(m)-len(order)]
+		ar = map[string]string{"": "0	0", "a": "1	0", "al": "1	1", "l": "0	1"}[order]
+		if base == "swp" {
+			return true, "1	0	0	0", ar, false
+		} else if len(base) > 2 && ops[base[2:]] != "" {
+			if strings.HasPrefix(base, "ld") {
+				return true, ops[base[2:]], ar, false
+			} else if strings.HasPrefix(base, "st") && (order == "" || order == "l") {
+				return true, ops[base[2:]], ar, true
+			}
+		}
+	}
+	return
+}
+
 func invertCond(cond int) int {
 	if cond < 14 { // AL / NV excluded
 		return cond ^ 1 // invert = flip bit 0
@@ -3074,6 +4770,48 @@ func getImm(imm string) (bool, int) {
 	return false, 0
 }
 
+// getFpImm parses a floating-point immediate such as "#1.5" or "#-2.0"
+func getFpImm(imm string) (bool, float64) {
+	if len(imm) > 1 && imm[0] == '#' {
+		if f, err := strconv.ParseFloat(imm[1:], 64); err == nil && !math.IsInf(f, 0) && !math.IsNaN(f) {
+			return true, f
+		}
+	}
+	return false, 0
+}
+
+// getFpImm8 returns the 8-bit encoding (abcdefgh) of a floating-point immediate,
+// i.e. a value of the form ±n/16 × 2^r with 16 <= n <= 31 and -3 <= r <= 4
+func getFpImm8(f float64) (bool, int) {
+	for imm8 := 0; imm8 < 256; imm8++ {
+		// VFPExpandImm: exponent is NOT(b):c:d with bias, fraction is efgh
+		exp := (imm8 >> 4) & 7
+		r := If(exp < 4, exp+1, exp-7)
+		v := math.Ldexp(float64(16+imm8&15)/16, r)
+		if imm8&0x80 != 0 {
+			v = -v
+		}
+		if v == f {
+			return true, imm8
+		}
+	}
+	return false, 0
+}
+
+// errSizePair reports an illegal destination/source element size combination
+func errSizePair(mnem, pairs, ins string) error {
+	return fmt.Errorf("illegal element size pair for %s, legal <Td>/<Tn> pairs are %s: %s", mnem, pairs, ins)
+}
+
+// errFpImm8 describes which floating-point immediates are encodable
+func errFpPrecision(mnem, ins string) error {
+	return fmt.Errorf("%s requires h, s or d registers of the same precision: %s", mnem, ins)
+}
+
+func errFpImm8(ins string) error {
+	return fmt.Errorf("floating-point immediate not encodable, must be ±n/16 × 2^r with 16 <= n <= 31 and -3 <= r <= 4 (e.g. #0.125 to #31.0): %s", ins)
+}
+
 // imms: imms is the number of bits **set**
 // immr: immr is the number of bits to **rotate**
 func getImm13(imms, immr uint32, T string) (imm13 uint32) {
@@ -3159,6 +4897,98 @@ func getSizeFromType(T string) string {
 	}
 }
 
+// getLoadStoreType returns the size and opc fields, the SIMD&FP (V) bit and the
+// access size (log2 bytes) for a scalar load/store of the register in args[0]
+func getLoadStoreType(mnem string, args []string) (ok bool, size, opc, v, scale int) {
+	if len(args) < 2 {
+		return
+	}
+	// LDUR<x>/STUR<x> share their fields with LDR<x>/STR<x>
+	name := strings.Replace(strings.Replace(mnem, "ldur", "ldr", 1), "stur", "str", 1)
+	load, suffix, rt := strings.HasPrefix(name, "ld"), name[3:], args[0]
+	if getR(rt) != -1 && rt != "sp" {
+		switch suffix {
+		case "":
+			size = If(rt[0] == 'x', 3, 2)
+			return true, size, If(load, 1, 0), 0, size
+		case "b", "h", "w": // ldrb/ldrh/ldrw zero-extend and accept either register width
+			size = strings.Index("bhw", suffix)
+			return true, size, If(load, 1, 0), 0, size
+		case "sb", "sh":
+			size = strings.Index("bh", suffix[1:])
+			return load, size, If(rt[0] == 'x', 2, 3), 0, size
+		case "sw":
+			return load && rt[0] == 'x', 2, 2, 0, 2
+		}
+	} else if getVQ(rt) != -1 && suffix == "" {
+		scale = getFpScale(rt[0])
+		return true, scale & 3, If(scale == 4, 2, 0) | If(load, 1, 0), 1, scale
+	}
+	return false, 0, 0, 0, 0
+}
+
+// assem_ldst assembles a scalar load/store for all addressing modes: unsigned
+// (scaled) offset, unscaled offset, pre/post-index, register offset and literal.
+// An offset that cannot be scaled falls back to the unscaled (LDUR/STUR) form.
+func assem_ldst(ins string, size, opc, v, scale int, unscaled bool, args []string) (opcode, opcode2 uint32, err error) {
+	rt := If(v == 1, getVQ(args[0]), getR(args[0]))
+	fields := func(templ string) string {
+		templ = strings.ReplaceAll(templ, "size", fmt.Sprintf("%0*s", 2, strconv.FormatUint(uint64(size), 2)))
+		templ = strings.ReplaceAll(templ, "opc", fmt.Sprintf("%0*s", 2, strconv.FormatUint(uint64(opc), 2)))
+		templ = strings.ReplaceAll(templ, "V", strconv.Itoa(v))
+		return strings.ReplaceAll(templ, "Rt", "Rd")
+	}
+
+	if ok, imm := is_i(args[1:]); ok && !unscaled {
+		// LDR <Xt>, <label> (literal), with the label given as a byte offset
+		litOpc := If(v == 1, scale-2, If(opc == 2, 2, size-2))
+		if !(v == 0 && (opc == 1 && size >= 2 || opc == 2 && size == 2) || v == 1 && opc&1 == 1 && scale >= 2) {
+			return 0, 0, fmt.Errorf("literal form only defined for ldr and ldrsw: %s", ins)
+		} else if imm&3 != 0 || imm < -1<<20 || imm >= 1<<20 {
+			return 0, 0, fmt.Errorf("literal offset must be a multiple of 4 in [-1048576, 1048572]: %s", ins)
+		}
+		templ := "opc	0	1	1	V	0	0	immhi	Rt"
+		size, opc = 0, litOpc
+		return assem_r_i(fields(templ), rt, "immhi", If(imm < 0, (1<<21)+imm, imm)>>2), 0, nil
+	} else if len(args) == 2 && !strings.HasPrefix(args[1], "[") {
+		return 0, 0, fmt.Errorf("literal form takes a byte offset (#<imm>), labels are resolved by sve-as and not by Assemble: %s", ins)
+	} else if rn, rm, option, amount := getMemAddrRegister(args[1:]); rn != -1 && rm != -1 && !unscaled {
+		// LDR <Xt>, [<Xn|SP>, (<Wm>|<Xm>){, <extend> {<amount>}}]
+		if option == -1 {
+			return 0, 0, fmt.Errorf("register offset extend must be uxtw, lsl, sxtw or sxtx: %s", ins)
+		}
+		if amount != -1 && amount != 0 && amount != scale {
+			return 0, 0, fmt.Errorf("register offset shift amount must be 0 or %d: %s", scale, ins)
+		}
+		templ := "size	1	1	1	V	0	0	opc	1	Rm	option	S	1	0	Rn	Rt"
+		templ = strings.ReplaceAll(templ, "option", fmt.Sprintf("%0*s", 3, strconv.FormatUint(uint64(option), 2)))
+		// for byte accesses an explicit #0 amount is encoded with S=1
+		templ = strings.ReplaceAll(templ, "S", If(amount != -1 && (amount != 0 || scale == 0), "1", "0"))
+		return assem_r_rr(fields(templ), rt, rn, rm, 0, "", 0), 0, nil
+	} else if ok, rn, imm, postIndex, writeBack := is_bi(args[1:]); ok {
+		if writeBack && !unscaled {
+			if imm < -256 || imm > 255 {
+				return 0, 0, fmt.Errorf("pre/post-index offset must be in [-256, 255]: %s", ins)
+			}
+			templ := "size	1	1	1	V	0	0	opc	0	imm9	x	1	Rn	Rt"
+			templ = strings.ReplaceAll(templ, "x", If(postIndex, "0", "1"))
+			return assem_r_ri(fields(templ), rt, rn, 0, "imm9", If(imm < 0, (1<<9)+imm, imm), 0), 0, nil
+		} else if !writeBack && !unscaled && imm&(1<<scale-1) == 0 && 0 <= imm && imm < 4096<<scale {
+			templ := "size	1	1	1	V	0	1	opc	imm12	Rn	Rt"
+			return assem_r_ri(fields(templ), rt, rn, 0, "imm12", imm>>scale, 0), 0, nil
+		} else if !writeBack && -256 <= imm && imm <= 255 {
+			// LDUR <Xt>, [<Xn|SP>{, #<simm>}]
+			templ := "size	1	1	1	V	0	0	opc	0	imm9	0	0	Rn	Rt"
+			return assem_r_ri(fields(templ), rt, rn, 0, "imm9", If(imm < 0, (1<<9)+imm, imm), 0), 0, nil
+		} else if !writeBack && unscaled {
+			return 0, 0, fmt.Errorf("unscaled offset must be in [-256, 255]: %s", ins)
+		} else if !writeBack {
+			return 0, 0, fmt.Errorf("offset must be a multiple of %d in [0, %d] or in [-256, 255]: %s", 1<<scale, 4095<<scale, ins)
+		}
+	}
+	return 0, 0, fmt.Errorf("unhandled instruction: %s", ins)
+}
+
 // ldstMultiTempl returns the encoding template for LD2/LD3/LD4 and ST2/ST3/ST4 structured
 // interleaved load/store instructions. nreg ∈ {2,3,4}, T ∈ {"b","h","s","d"}.
 func ldstMultiTempl(nreg int, T string, isStore bool, isReg bool) string {
@@ -3229,6 +5059,174 @@ func sme2St1xTempl(T string, nreg int, isReg bool) string {
 	return templ
 }
 
+// sme2ZaTempl returns the encoding template for the SME2 ZA array forms that
+// take either a multi-vector group plus a single vector (multi=false) or two
+// multi-vector groups (multi=true).
+// vgx=1 selects the single ZA vector form (only valid for multi=false),
+// opc encodes bits 12-10 and op bits 4-3 of the instruction.
+func sme2ZaTempl(multi bool, vgx int, sz, opc, op string) string {
+	var templ string
+	if !multi {
+		templ = "1	1	0	0	0	0	0	1	0	sz	1	vg	Zm4	0	Rv	opc	Zn	op	off3"
+		templ = strings.ReplaceAll(templ, "vg", If(vgx == 4, "1", "0"))
+	} else if vgx == 2 {
+		templ = "1	1	0	0	0	0	0	1	1	sz	1	Zm4	0	0	Rv	opc	Zn4	0	op	off3"
+	} else if vgx == 4 {
+		templ = "1	1	0	0	0	0	0	1	1	sz	1	Zm3	0	1	0	Rv	opc	Zn3	0	0	op	off3"
+	} else {
+		return ""
+	}
+	templ = strings.ReplaceAll(templ, "sz", sz)
+	templ = strings.ReplaceAll(templ, "opc", opc)
+	templ = strings.ReplaceAll(templ, "op", op)
+	return templ
+}
+
+// sme2DotSpecifier returns the sz (bit 22) and op (bits 4-3) fields of the SME2
+// SDOT/UDOT ZA array forms for the given ZA and source element types:
+// 4-way B to S, 4-way H to D and 2-way H to S.
+func sme2DotSpecifier(mnem, Tza, T string) (sz, op string, ok bool) {
+	u := If(mnem == "udot", "1", "0")
+	switch Tza + T {
+	case "sb":
+		return "0", u + "	0", true
+	case "dh":
+		return "1", u + "	0", true
+	case "sh":
+		return "1", u + "	1", true
+	}
+	return "", "", false
+}
+
+// getZaArray parses an SME2 ZA array vector select such as `za.s[w8, 0, vgx2]`,
+// `za.d[w11, 7]` or `za.s[w8, 0:1, vgx4]`.
+// It returns the number of arguments consumed (0 when there is no match), the
+// element type, the vector select register (w8-w11 as 0-3) and the offset(s).
+func getZaArray(args []string) (n int, T string, rv, offs, offs2, vgx int) {
+	if len(args) == 0 || !strings.HasPrefix(args[0], "za") {
+		return
+	}
+	end := 0
+	for end < len(args) && !strings.HasSuffix(args[end], "]") {
+		end++
+	}
+	if end == len(args) {
+		return
+	}
+	sel := strings.Join(args[:end+1], " ")
+	open := strings.Index(sel, "[")
+	if open == -1 {
+		return
+	}
+	switch za := sel[:open]; za {
+	case "za", "za.b", "za.h", "za.s", "za.d", "za.q":
+		T = strings.TrimPrefix(strings.TrimPrefix(za, "za"), ".")
+	default:
+		return 0, "", 0, 0, 0, 0
+	}
+	fields := strings.Fields(sel[open+1 : len(sel)-1])
+	if len(fields) < 2 || len(fields) > 3 || fields[0][0] != 'w' {
+		return 0, "", 0, 0, 0, 0
+	}
+	if rv = getR(fields[0]) - 8; rv < 0 || rv > 3 {
+		return 0, "", 0, 0, 0, 0
+	}
+	first, last, isRange := strings.Cut(strings.TrimPrefix(fields[1], "#"), ":")
+	var err error
+	if offs, err = strconv.Atoi(first); err != nil || offs < 0 {
+		return 0, "", 0, 0, 0, 0
+	}
+	offs2 = offs
+	if isRange {
+		if offs2, err = strconv.Atoi(last); err != nil || offs2 < offs {
+			return 0, "", 0, 0, 0, 0
+		}
+	}
+	vgx = 1
+	if len(fields) == 3 {
+		switch fields[2] {
+		case "vgx2":
+			vgx = 2
+		case "vgx4":
+			vgx = 4
+		default:
+			return 0, "", 0, 0, 0, 0
+		}
+	}
+	return end + 1, T, rv, offs, offs2, vgx
+}
+
+// getZList parses a list of Z registers, either as a range `{ z0.s-z3.s }`, as
+// an enumeration `{ z0.s, z1.s }` (including strided lists like
+// `{ z0.s, z8.s }`) or as a single unbraced register `z0.s`.
+// Registers wrap around modulo 32; the distance between successive registers
+// is returned as stride. It returns the number of arguments consumed (0 when
+// there is no match).
+func getZList(args []string) (n, zn, count, stride int, T string) {
+	if len(args) >= 1 && args[0] != "{" {
+		if zn, T, _ = getZ(args[0]); zn != -1 && T != "" && !strings.Contains(args[0], "[") {
+			return 1, zn, 1, 1, T
+		}
+		return 0, -1, 0, 0, ""
+	}
+	end := 1
+	for end < len(args) && args[end] != "}" {
+		end++
+	}
+	if end >= len(args) || end == 1 {
+		return 0, -1, 0, 0, ""
+	}
+	list := args[1:end]
+	if joined := strings.Join(list, ""); strings.Contains(joined, "-") {
+		parts := strings.Split(joined, "-")
+		if len(parts) == 2 {
+			first, T1, _ := getZ(parts[0])
+			last, T2, _ := getZ(parts[1])
+			if first != -1 && last != -1 && T1 != "" && T1 == T2 {
+				return end + 1, first, (last-first+32)%32 + 1, 1, T1
+			}
+		}
+		return 0, -1, 0, 0, ""
+	}
+	stride = 1
+	for i, reg := range list {
+		z, Tz, _ := getZ(reg)
+		if z == -1 || Tz == "" || strings.Contains(reg, "[") {
+			return 0, -1, 0, 0, ""
+		}
+		if i == 0 {
+			zn, T = z, Tz
+		} else if Tz != T {
+			return 0, -1, 0, 0, ""
+		} else if i == 1 {
+			stride = (z - zn + 32) % 32
+		} else if z != (zn+i*stride)%32 {
+			return 0, -1, 0, 0, ""
+		}
+	}
+	if stride == 0 {
+		return 0, -1, 0, 0, ""
+	}
+	return end + 1, zn, len(list), stride, T
+}
+
+// isZGroup reports whether the count registers starting at zn and spaced
+// stride apart form a valid SME2 multi-vector group.
+// Consecutive groups (stride 1) must start at a multiple of count when aligned
+// is set; strided groups are confined to z0-z15 or z16-z31 and use a stride of
+// 16/count (so {z0-z7, z16-z23} + 8 or {z0-z3, z16-z19} + 4, 8, 12).
+func isZGroup(zn, count, stride int, aligned bool) bool {
+	switch {
+	case count != 2 && count != 4:
+		return false
+	case stride == 1:
+		return !aligned || zn%count == 0
+	case stride == 16/count:
+		return zn&15 < stride
+	}
+	return false
+}
+
 func getTypeSpecifier(T string, index int) (string, int, bool) {
 	switch strings.ToUpper(T) {
 	case "B":
@@ -3286,7 +5284,7 @@ func computeShiftSpecifier(imm uint, reverse bool, T string) (int, string) {
 	switch strings.ToUpper(T) {
 	case "B":
 		const esize = 8
-		if imm < esize {
+		if imm < esize || reverse && imm == esize {
 			if reverse {
 				imm = esize - imm
 			}
@@ -3294,7 +5292,7 @@ func computeShiftSpecifier(imm uint, reverse bool, T string) (int, string) {
 		}
 	case "H":
 		const esize = 16
-		if imm < esize {
+		if imm < esize || reverse && imm == esize {
 			if reverse {
 				imm = esize - imm
 			}
@@ -3302,7 +5300,7 @@ func computeShiftSpecifier(imm uint, reverse bool, T string) (int, string) {
 		}
 	case "S":
 		const esize = 32
-		if imm < esize {
+		if imm < esize || reverse && imm == esize {
 			if reverse {
 				imm = esize - imm
 			}
@@ -3310,7 +5308,7 @@ func computeShiftSpecifier(imm uint, reverse bool, T string) (int, string) {
 		}
 	case "D":
 		const esize = 64
-		if imm < esize {
+		if imm < esize || reverse && imm == esize {
 			if reverse {
 				imm = esize - imm
 			}
@@ -3456,18 +5454,95 @@ func is_r_r_cond(args []string) (ok bool, rd, rn, cond, sf int) {
 			return true, rd, rn, cond, sfBit(args[0])
 		}
 	}
-	return
+	return
+}
+
+func is_r_rr_cond(args []string) (ok bool, rd, rn, rm, cond, sf int) {
+	if len(args) == 4 {
+		rd, rn, rm = getR(args[0]), getR(args[1]), getR(args[2])
+		cond = getCond(args[3])
+		if rd != -1 && rn != -1 && rm != -1 && cond != -1 {
+			return true, rd, rn, rm, cond, sfBit(args[0])
+		}
+	}
+	return
+}
+
+func is_rr_nzcv_cond(args []string) (ok bool, rn, rm, nzcv, cond, sf int) {
+	if len(args) == 4 {
+		rn, rm = getR(args[0]), getR(args[1])
+		cond = getCond(args[3])
+		if ok, nzcv = getImm(args[2]); ok && rn != -1 && rm != -1 && cond != -1 && 0 <= nzcv && nzcv <= 15 {
+			return true, rn, rm, nzcv, cond, sfBit(args[0])
+		}
+	}
+	return false, 0, 0, 0, 0, 0
+}
+
+func is_ri_nzcv_cond(args []string) (ok bool, rn, imm, nzcv, cond, sf int) {
+	if len(args) == 4 {
+		rn = getR(args[0])
+		cond = getCond(args[3])
+		if okImm, imm := getImm(args[1]); okImm && rn != -1 && cond != -1 {
+			if ok, nzcv = getImm(args[2]); ok && 0 <= nzcv && nzcv <= 15 {
+				return true, rn, imm, nzcv, cond, sfBit(args[0])
+			}
+		}
+	}
+	return false, 0, 0, 0, 0, 0
+}
+
+func is_f_f(args []string) (ok bool, rd, rn int, Td, Tn string) {
+	if len(args) == 2 {
+		rd, rn = getV(args[0]), getV(args[1])
+		Td, Tn = getFpType(args[0]), getFpType(args[1])
+		if Td != "" && Tn != "" {
+			return true, rd, rn, Td, Tn
+		}
+	}
+	return false, 0, 0, "", ""
+}
+
+func is_f_ff(args []string) (ok bool, rd, rn, rm int, ftype string) {
+	if len(args) == 3 {
+		rd, rn, rm = getV(args[0]), getV(args[1]), getV(args[2])
+		ftype = getFpType(args[0])
+		if ftype != "" && getFpType(args[1]) == ftype && getFpType(args[2]) == ftype {
+			return true, rd, rn, rm, ftype
+		}
+	}
+	return false, 0, 0, 0, ""
+}
+
+func is_f_fff(args []string) (ok bool, rd, rn, rm, ra int, ftype string) {
+	if len(args) == 4 {
+		if ok, rd, rn, rm, ftype = is_f_ff(args[:3]); ok && getFpType(args[3]) == ftype {
+			return true, rd, rn, rm, getV(args[3]), ftype
+		}
+	}
+	return false, 0, 0, 0, 0, ""
+}
+
+func is_f_ff_cond(args []string) (ok bool, rd, rn, rm, cond int, ftype string) {
+	if len(args) == 4 {
+		cond = getCond(args[3])
+		if ok, rd, rn, rm, ftype = is_f_ff(args[:3]); ok && cond != -1 {
+			return true, rd, rn, rm, cond, ftype
+		}
+	}
+	return false, 0, 0, 0, 0, ""
 }
 
-func is_r_rr_cond(args []string) (ok bool, rd, rn, rm, cond, sf int) {
+func is_ff_nzcv_cond(args []string) (ok bool, rn, rm, nzcv, cond int, ftype string) {
 	if len(args) == 4 {
-		rd, rn, rm = getR(args[0]), getR(args[1]), getR(args[2])
+		rn, rm = getV(args[0]), getV(args[1])
+		ftype = getFpType(args[0])
 		cond = getCond(args[3])
-		if rd != -1 && rn != -1 && rm != -1 && cond != -1 {
-			return true, rd, rn, rm, cond, sfBit(args[0])
+		if ok, nzcv = getImm(args[2]); ok && ftype != "" && getFpType(args[1]) == ftype && cond != -1 && 0 <= nzcv && nzcv <= 15 {
+			return true, rn, rm, nzcv, cond, ftype
 		}
 	}
-	return
+	return false, 0, 0, 0, 0, ""
 }
 
 func is_r_rrr(args []string) (ok bool, rd, rn, rm, ra, sf int) {
@@ -3509,6 +5584,17 @@ func is_r_v(args []string) (ok bool, rd, rn, sf int) {
 	return
 }
 
+func is_v_r(args []string) (ok bool, vd, rn, sf int) {
+	if len(args) == 2 {
+		vd = getV(args[0])
+		rn = getR(args[1])
+		if vd != -1 && rn != -1 {
+			return true, vd, rn, sfBit(args[1])
+		}
+	}
+	return
+}
+
 func is_r_ri(args []string) (ok bool, rd, rn, imm, shift, sf int) {
 	if len(args) >= 3 {
 		rd, rn = getR(args[0]), getR(args[1])
@@ -3600,6 +5686,19 @@ func is_bi(args []string) (ok bool, xn, imm int, postIndex, writeBack bool) {
 	return
 }
 
+func is_vv_bi(args []string) (ok bool, vt, vt2, xn, imm int, width byte, postIndex, writeBack bool) {
+	if len(args) >= 3 {
+		vt = getVQ(args[0])
+		vt2 = getVQ(args[1])
+		if vt != -1 && vt2 != -1 && args[0][0] == args[1][0] {
+			if ok, xn, imm, postIndex, writeBack = is_bi(args[2:]); ok {
+				return true, vt, vt2, xn, imm, args[0][0], postIndex, writeBack
+			}
+		}
+	}
+	return
+}
+
 func is_rr_bi(args []string) (ok bool, rt, rt2, xn, imm int, postIndex, writeBack bool) {
 	if len(args) >= 3 {
 		rt = getR(args[0])
@@ -3613,32 +5712,167 @@ func is_rr_bi(args []string) (ok bool, rt, rt2, xn, imm int, postIndex, writeBac
 	return
 }
 
-func is_r_br(args []string) (ok bool, rt, rn, rm, option, amount, sf int) {
+func is_r_r_b(args []string) (ok bool, rt, rs, rn int) {
+	if len(args) >= 3 {
+		rs = getR(args[0])
+		rt = getR(args[1])
+		if rs != -1 && rt != -1 {
+			var imm int
+			if rn, imm = getMemAddrImm(args[2:]); rn != -1 && imm == 0 {
+				return true, rt, rs, rn
+			}
+		}
+	}
+	return
+}
+
+func is_r_b(args []string) (ok bool, rt, rn int) {
 	if len(args) >= 2 {
 		rt = getR(args[0])
-		if rt != -1 && args[1][0] == '[' && strings.HasSuffix(args[len(args)-1], "]") {
-			if rn, rm, option, amount = getMemAddrRegister(args[1:]); rn != -1 && rm != -1 {
-				return true, rt, rn, rm, option, amount, sfBit(args[0])
+		if rt != -1 {
+			var imm int
+			if rn, imm = getMemAddrImm(args[1:]); rn != -1 && imm == 0 {
+				return true, rt, rn
 			}
 		}
 	}
 	return
 }
 
-func is_r_r_b(args []string) (ok bool, rt, rs, rn int) {
-	if len(args) >= 3 {
+func is_r_rr_b(args []string) (ok bool, rs, rt, rt2, rn int) {
+	if len(args) >= 4 {
 		rs = getR(args[0])
 		rt = getR(args[1])
-		if rs != -1 && rt != -1 {
+		rt2 = getR(args[2])
+		if rs != -1 && rt != -1 && rt2 != -1 {
 			var imm int
-			if rn, imm = getMemAddrImm(args[2:]); rn != -1 && imm == 0 {
-				return true, rt, rs, rn
+			if rn, imm = getMemAddrImm(args[3:]); rn != -1 && imm == 0 {
+				return true, rs, rt, rt2, rn
 			}
 		}
 	}
 	return
 }
 
+func is_v_vv(args []string) (ok bool, vd, vn, vm int, T string) {
+	if len(args) == 3 {
+		vd, T, i1 := getVT(args[0])
+		vn, Tn, i2 := getVT(args[1])
+		vm, Tm, i3 := getVT(args[2])
+		if vd != -1 && vn != -1 && vm != -1 && i1 == -1 && i2 == -1 && i3 == -1 && T == Tn && T == Tm {
+			return true, vd, vn, vm, T
+		}
+	}
+	return false, 0, 0, 0, ""
+}
+
+func is_v_v(args []string) (ok bool, vd, vn int, T string) {
+	if len(args) == 2 {
+		vd, T, i1 := getVT(args[0])
+		vn, Tn, i2 := getVT(args[1])
+		if vd != -1 && vn != -1 && i1 == -1 && i2 == -1 && T == Tn {
+			return true, vd, vn, T
+		}
+	}
+	return false, 0, 0, ""
+}
+
+func is_v_v0(args []string) (ok bool, vd, vn int, T string) {
+	if len(args) == 3 && args[2] == "#0" {
+		return is_v_v(args[:2])
+	}
+	return false, 0, 0, ""
+}
+
+// is_v_v_long matches two vector registers with (possibly) different arrangements
+func is_v_v_long(args []string) (ok bool, vd, vn int, Td, Tn string) {
+	if len(args) == 2 {
+		vd, Td, i1 := getVT(args[0])
+		vn, Tn, i2 := getVT(args[1])
+		if vd != -1 && vn != -1 && i1 == -1 && i2 == -1 {
+			return true, vd, vn, Td, Tn
+		}
+	}
+	return false, 0, 0, "", ""
+}
+
+func is_v_vv_elem(args []string) (ok bool, vd, vn, vm, index int, T string) {
+	if len(args) == 3 {
+		if ok, vd, vn, T := is_v_v(args[:2]); ok {
+			if vm, Ts, index := getVT(args[2]); vm != -1 && index != -1 && len(Ts) == 1 && strings.HasSuffix(T, Ts) {
+				return true, vd, vn, vm, index, T
+			}
+		}
+	}
+	return false, 0, 0, 0, 0, ""
+}
+
+func is_vs_v(args []string) (ok bool, vd, vn int, T string) {
+	if len(args) == 2 {
+		vd = getV(args[0])
+		vn, T, index := getVT(args[1])
+		if vd != -1 && vn != -1 && index == -1 && args[0][0] != 'q' {
+			return true, vd, vn, T
+		}
+	}
+	return false, 0, 0, ""
+}
+
+func is_v_velem(args []string) (ok bool, vd, vn, index int, T, Ts string) {
+	if len(args) == 2 {
+		vd, T, i1 := getVT(args[0])
+		vn, Ts, index := getVT(args[1])
+		if vd != -1 && vn != -1 && i1 == -1 && index != -1 {
+			return true, vd, vn, index, T, Ts
+		}
+	}
+	return false, 0, 0, 0, "", ""
+}
+
+func is_vt_r(args []string) (ok bool, vd, rn int, T string) {
+	if len(args) == 2 {
+		vd, T, index := getVT(args[0])
+		rn = getR(args[1])
+		if vd != -1 && index == -1 && rn != -1 && args[1] != "sp" {
+			return true, vd, rn, T
+		}
+	}
+	return false, 0, 0, ""
+}
+
+func is_velem_r(args []string) (ok bool, vd, index, rn int, Ts string) {
+	if len(args) == 2 {
+		vd, Ts, index := getVT(args[0])
+		rn = getR(args[1])
+		if vd != -1 && index != -1 && rn != -1 && args[1] != "sp" {
+			return true, vd, index, rn, Ts
+		}
+	}
+	return false, 0, 0, 0, ""
+}
+
+func is_velem_velem(args []string) (ok bool, vd, index, vn, index2 int, Ts string) {
+	if len(args) == 2 {
+		vd, Ts, index := getVT(args[0])
+		vn, Tn, index2 := getVT(args[1])
+		if vd != -1 && vn != -1 && index != -1 && index2 != -1 && Ts == Tn {
+			return true, vd, index, vn, index2, Ts
+		}
+	}
+	return false, 0, 0, 0, 0, ""
+}
+
+func is_r_velem(args []string) (ok bool, rd, vn, index int, Ts string) {
+	if len(args) == 2 {
+		rd = getR(args[0])
+		vn, Ts, index := getVT(args[1])
+		if rd != -1 && args[0] != "sp" && vn != -1 && index != -1 {
+			return true, rd, vn, index, Ts
+		}
+	}
+	return false, 0, 0, 0, ""
+}
+
 func is_v_p_z(args []string) (ok bool, vd, pg, zn int, T string) {
 	if len(args) == 3 {
 		vd = getV(args[0])
@@ -3705,6 +5939,19 @@ func is_z_z(args []string) (ok bool, zd, zn int, T string) {
 	return
 }
 
+func is_z_zimm_tt(args []string) (ok bool, zd, zn, imm int, Td, Tn string) {
+	if len(args) == 3 {
+		zd, Td, _ = getZ(args[0])
+		zn, Tn, _ = getZ(args[1])
+		if zd != -1 && zn != -1 {
+			if ok, imm := getImm(args[2]); ok {
+				return true, zd, zn, imm, Td, Tn
+			}
+		}
+	}
+	return
+}
+
 func is_z_z_tt(args []string) (ok bool, zd, zn int, Td, Tn string) {
 	if len(args) == 2 {
 		zd, Td, _ = getZ(args[0])
@@ -3773,6 +6020,19 @@ func is_z_zi(args []string) (ok bool, zd, zn, imm, shift int, T string) {
 	return
 }
 
+func is_z_zz_indexed(args []string) (ok bool, zd, zn, zm, index int, T string) {
+	if len(args) == 3 && strings.HasSuffix(args[2], "]") {
+		var t1, t2, t3 string
+		zd, t1, _ = getZ(args[0])
+		zn, t2, _ = getZ(args[1])
+		zm, t3, index = getZ(args[2])
+		if zd != -1 && zn != -1 && zm != -1 && index >= 0 && t1 == t2 && t2 == t3 {
+			return true, zd, zn, zm, index, t1
+		}
+	}
+	return
+}
+
 func is_z_zz_2t(args []string) (ok bool, zd, zn, zm int, Td, T string) {
 	if len(args) == 3 {
 		var td, t2, t3 string
@@ -3888,7 +6148,7 @@ func is_p_p_zz(args []string) (ok bool, pd, pg, zn, zm int, T string) {
 		pg = getP(strings.Split(args[1], "/")[0]) // drop any trailer
 		zn, t2, _ = getZ(args[2])
 		zm, t3, _ = getZ(args[3])
-		if pd != -1 && pg != -1 && zn != -1 && zm != -1 && t1 == t2 && t2 == t3 {
+		if pd != -1 && pg != -1 && zn != -1 && zm != -1 && t1 == t2 && t2 == t3 && is_zeroing(args[1]) {
 			return true, pd, pg, zn, zm, t2
 		}
 	}
@@ -3902,7 +6162,7 @@ func is_p_p_zi(args []string) (ok bool, pd, pg, zn, imm int, T string) {
 		pg = getP(strings.Split(args[1], "/")[0]) // drop any trailer
 		zn, t2, _ = getZ(args[2])
 		if ok, imm := getImm(args[3]); ok {
-			if pd != -1 && pg != -1 && zn != -1 && t1 == t2 {
+			if pd != -1 && pg != -1 && zn != -1 && t1 == t2 && is_zeroing(args[1]) {
 				return true, pd, pg, zn, imm, t1
 			}
 		}
@@ -3910,6 +6170,20 @@ func is_p_p_zi(args []string) (ok bool, pd, pg, zn, imm int, T string) {
 	return
 }
 
+// is_p_p_z0 parses the compare with zero form: <Pd>.<T>, <Pg>/Z, <Zn>.<T>, #0.0
+func is_p_p_z0(args []string) (ok bool, pd, pg, zn int, T string) {
+	if len(args) == 4 && (args[3] == "#0.0" || args[3] == "#0") {
+		var t2 string
+		pd, T = getPdes(args[0])
+		pg = getP(strings.Split(args[1], "/")[0]) // drop any trailer
+		zn, t2, _ = getZ(args[2])
+		if pd != -1 && pg != -1 && zn != -1 && T == t2 && is_zeroing(args[1]) {
+			return true, pd, pg, zn, T
+		}
+	}
+	return
+}
+
 func is_z_p_zz(args []string) (ok bool, zdn, pg, zm int, T string) {
 	if len(args) == 4 {
 		var t1, t2, t3 string
@@ -3942,6 +6216,20 @@ func is_z_p_zi(args []string) (ok bool, zdn, pg, imm int, T string) {
 	return
 }
 
+func is_z_p_zf(args []string) (ok bool, zd, pg, zn int, f float64, T string) {
+	if len(args) == 4 {
+		var t1, t2 string
+		zd, t1, _ = getZ(args[0])
+		pg = getP(strings.Split(args[1], "/")[0]) // drop any trailer
+		zn, t2, _ = getZ(args[2])
+		okImm, fVal := getFpImm(args[3])
+		if okImm && zd != -1 && pg != -1 && zn != -1 && t1 == t2 {
+			return true, zd, pg, zn, fVal, t1
+		}
+	}
+	return
+}
+
 func is_z2_p_zz(args []string) (ok bool, zdn, pg, zm, za int, T string) {
 	if len(args) == 4 {
 		var t1, t2, t3 string
@@ -3973,6 +6261,9 @@ func assem_prefixed_z_z(ins string, zd, zn int) (opcode, opcode2 uint32, err err
 	return 0, 0, fmt.Errorf("unhandled 'MOVPRFX (unpredicated)' instruction: %s", ins)
 }
 
+// is_prefixed_z_p_zz matches `<op> Zd, Pg/<ZM>, Zn, Zm` for a destructive
+// instruction that needs a MOVPRFX of Zn into Zd; Zm cannot be Zd (unless Zn is
+// too), as the MOVPRFX would overwrite it before the operation reads it
 func is_prefixed_z_p_zz(args []string) (ok bool, zd, pg, zn, zm int, T string) {
 	if len(args) == 4 {
 		var t1, t2, t3 string
@@ -3981,7 +6272,7 @@ func is_prefixed_z_p_zz(args []string) (ok bool, zd, pg, zn, zm int, T string) {
 		zn, t2, _ = getZ(args[2])
 		zm, t3, _ = getZ(args[3])
 
-		if zd != -1 && zn != -1 && zm != -1 && pg != -1 && t1 == t2 && t2 == t3 {
+		if zd != -1 && zn != -1 && zm != -1 && pg != -1 && t1 == t2 && t2 == t3 && (zm != zd || zn == zd) {
 			return true, zd, pg, zn, zm, t1
 		}
 	}
@@ -4058,6 +6349,33 @@ func is_z_i(args []string) (ok bool, zd, imm int, T string) {
 	return
 }
 
+func is_z_f(args []string) (ok bool, zd int, f float64, T string) {
+	if len(args) == 2 {
+		var t1 string
+		zd, t1, _ = getZ(args[0])
+		if zd != -1 {
+			if ok, f := getFpImm(args[1]); ok {
+				return true, zd, f, t1
+			}
+		}
+	}
+	return
+}
+
+func is_z_p_f(args []string) (ok bool, zd, pg int, f float64, T string) {
+	if len(args) == 3 {
+		var t1 string
+		zd, t1, _ = getZ(args[0])
+		pg = getP(strings.Split(args[1], "/")[0]) // drop any trailer
+		if zd != -1 && pg != -1 {
+			if ok, f := getFpImm(args[2]); ok {
+				return true, zd, pg, f, t1
+			}
+		}
+	}
+	return
+}
+
 func is_z_ii(args []string) (ok bool, zd, imm1, imm2 int, T string) {
 	if len(args) == 3 {
 		var t1 string
@@ -4171,6 +6489,33 @@ func is_i(args []string) (ok bool, imm int) {
 	return
 }
 
+func is_barrier_option(args []string) (ok bool, option int) {
+	if len(args) == 1 {
+		options := map[string]int{
+			"oshld": 1, "oshst": 2, "osh": 3,
+			"nshld": 5, "nshst": 6, "nsh": 7,
+			"ishld": 9, "ishst": 10, "ish": 11,
+			"ld": 13, "st": 14, "sy": 15,
+		}
+		if option, ok := options[args[0]]; ok {
+			return true, option
+		} else if ok, imm := getImm(args[0]); ok && 0 <= imm && imm <= 15 {
+			return true, imm
+		}
+	}
+	return
+}
+
+func is_r_sysreg(args []string) (ok bool, rt, sysreg int) {
+	if len(args) == 2 {
+		rt = getR(args[0])
+		if ok, sysreg = getSysReg(args[1]); ok && rt != -1 && args[0] != "sp" {
+			return true, rt, sysreg
+		}
+	}
+	return false, 0, 0
+}
+
 func is_r_i(args []string) (ok bool, rd int, imm, shift int) {
 	if len(args) == 2 || len(args) == 4 {
 		rd = getR(args[0])
@@ -4224,19 +6569,26 @@ func getMemAddrRegister(args []string) (rn, rm, option, amount int) {
 			if rn != -1 {
 				rm = getR(mas[1])
 				if rm != -1 {
-					mas = mas[2:]
-					if len(mas) == 0 { // Implicit register offset form: [Xn, Xm] == [Xn, Xm, LSL #0]
-						option, amount = 0b011, 0
-						return rn, rm, option, amount
-					} else if len(mas) == 2 && strings.ToLower(mas[0]) == "lsl" {
-						if ok, imm := getImm(mas[1]); ok {
-							// option	<extend>
-							// 010	UXTW
-							// 011	LSL
-							// 110	SXTW
-							// 111	SXTX
-							option, amount = 0b011, imm
-							return rn, rm, option, amount
+					// option	<extend>
+					// 010	UXTW
+					// 011	LSL
+					// 110	SXTW
+					// 111	SXTX
+					// (amount is -1 when no shift amount is given)
+					wm := mas[1][0] == 'w'
+					if len(mas) == 2 { // Implicit register offset form: [Xn, Xm] == [Xn, Xm, LSL #0]
+						mas = append(mas, "lsl")
+					}
+					option = getExtend(mas[2])
+					if option == -1 && mas[1] != "sp" {
+						return rn, rm, -1, -1 // unknown extend/shift, reported by the caller
+					} else if option&0b010 == 0 || wm != (option&1 == 0) || mas[1] == "sp" {
+						return -1, -1, -1, -1
+					} else if len(mas) == 3 && (option != 0b011 || len(args) == 2) {
+						return rn, rm, option, -1
+					} else if len(mas) == 4 {
+						if ok, imm := getImm(mas[3]); ok && imm >= 0 {
+							return rn, rm, option, imm
 						}
 					}
 				}
@@ -4591,6 +6943,82 @@ func is_zN_p_rr_mv(n int, args []string) (ok bool, zt, pg, rn, rm, shift int, T
 	return
 }
 
+// is_za_zz parses the SME2 ZA array multiple and single vector form:
+//
+//	za.T[wv, offs{, vgxN}], { zn1.T-znN.T }, zm.T
+//
+// The group of zn registers must be consecutive (with wrap-around).
+func is_za_zz(args []string) (ok bool, Tza string, rv, offs, offs2, vgx, zn, nn int, T string, zm int) {
+	var n, stride, m, mm int
+	var Tm string
+	if n, Tza, rv, offs, offs2, vgx = getZaArray(args); n == 0 {
+		return
+	}
+	if m, zn, nn, stride, T = getZList(args[n:]); m == 0 || stride != 1 || nn != vgx {
+		return
+	}
+	if mm, zm, _, _, Tm = getZList(args[n+m:]); mm == 1 && n+m+mm == len(args) && args[n+m] != "{" && Tm == T {
+		ok = true
+	}
+	return
+}
+
+// is_za_zzn parses the SME2 ZA array multiple vectors form:
+//
+//	za.T[wv, offs, vgxN], { zn1.T-znN.T }, { zm1.T-zmN.T }
+//
+// Both groups must be consecutive and aligned to the number of vectors.
+func is_za_zzn(args []string) (ok bool, Tza string, rv, offs, offs2, vgx, zn, zm, nn int, T string) {
+	var n, stride, m, mm, nm, stridem int
+	var Tm string
+	if n, Tza, rv, offs, offs2, vgx = getZaArray(args); n == 0 || vgx == 1 {
+		return
+	}
+	if m, zn, nn, stride, T = getZList(args[n:]); m == 0 || nn != vgx || !isZGroup(zn, nn, stride, true) || stride != 1 {
+		return
+	}
+	if mm, zm, nm, stridem, Tm = getZList(args[n+m:]); mm != 0 && n+m+mm == len(args) && Tm == T && nm == nn && isZGroup(zm, nm, stridem, true) && stridem == 1 {
+		ok = true
+	}
+	return
+}
+
+// is_za_zzi parses the SME2 ZA array multiple and indexed vector form:
+//
+//	za.T[wv, offs, vgxN], { zn1.T-znN.T }, zm.T[index]
+//
+// The group of zn registers must be consecutive and aligned.
+func is_za_zzi(args []string) (ok bool, Tza string, rv, offs, offs2, vgx, zn, nn int, T string, zm, index int) {
+	var n, stride, m int
+	var Tm string
+	if n, Tza, rv, offs, offs2, vgx = getZaArray(args); n == 0 || vgx == 1 {
+		return
+	}
+	if m, zn, nn, stride, T = getZList(args[n:]); m == 0 || nn != vgx || !isZGroup(zn, nn, stride, true) || stride != 1 {
+		return
+	}
+	if n+m+1 == len(args) && strings.HasSuffix(args[n+m], "]") {
+		if zm, Tm, index = getZ(args[n+m]); zm != -1 && Tm == T {
+			ok = true
+		}
+	}
+	return
+}
+
+// is_za_z parses the SME2 ZA array vectors form (as used by ADD/SUB array accumulators):
+//
+//	za.T[wv, offs, vgxN], { zm1.T-zmN.T }
+func is_za_z(args []string) (ok bool, Tza string, rv, offs, vgx, zm, nn int, T string) {
+	var n, m, stride int
+	if n, Tza, rv, offs, _, vgx = getZaArray(args); n == 0 || vgx == 1 {
+		return
+	}
+	if m, zm, nn, stride, T = getZList(args[n:]); m != 0 && n+m == len(args) && nn == vgx && isZGroup(zm, nn, stride, true) && stride == 1 {
+		ok = true
+	}
+	return
+}
+
 func is_z_p_rr(args []string) (ok bool, zt, pg, rn, rm, shift int, T string) {
 	if len(args) == 8 && args[0] == "{" && args[2] == "}" && strings.ToLower(args[6]) == "lsl" && (args[7] == "#3]" || args[7] == "#2]" || args[7] == "#1]") {
 		zt, T, _ = getZ(args[1])
@@ -4749,6 +7177,51 @@ func is_z2_pt(args []string) (ok bool, zdn, pn int, T string) {
 	return true, zdn, p, T
 }
 
+// assem_z_p_fimm1 encodes the predicated floating-point arithmetic with one-bit immediate
+// <Zdn>.<T>, <Pg>/M, <Zdn>.<T>, #<const>: 01100101 size 011 opc 100 Pg 0000 i1 Zdn
+func assem_z_p_fimm1(ins, mnem string, zdn, pg int, f float64, T string) (opcode, opcode2 uint32, err error) {
+	var opc string
+	var zero, one float64
+	switch mnem {
+	case "fadd":
+		opc, zero, one = "0	0	0", 0.5, 1.0
+	case "fsub":
+		opc, zero, one = "0	0	1", 0.5, 1.0
+	case "fmul":
+		opc, zero, one = "0	1	0", 0.5, 2.0
+	case "fsubr":
+		opc, zero, one = "0	1	1", 0.5, 1.0
+	case "fmaxnm":
+		opc, zero, one = "1	0	0", 0.0, 1.0
+	case "fminnm":
+		opc, zero, one = "1	0	1", 0.0, 1.0
+	case "fmax":
+		opc, zero, one = "1	1	0", 0.0, 1.0
+	case "fmin":
+		opc, zero, one = "1	1	1", 0.0, 1.0
+	}
+	i1 := ""
+	if f == zero && !math.Signbit(f) {
+		i1 = "0"
+	} else if f == one {
+		i1 = "1"
+	} else {
+		return 0, 0, fmt.Errorf("invalid immediate for %s, only #%.1f and #%.1f are encodable: %s", mnem, zero, one, ins)
+	}
+	templ := "0	1	1	0	0	1	0	1	size	0	1	1	opc	1	0	0	Pg	0	0	0	0	i1	Zdn"
+	templ = strings.ReplaceAll(templ, "size", getSizeFromType(T))
+	templ = strings.ReplaceAll(templ, "opc", opc)
+	templ = strings.ReplaceAll(templ, "i1", i1)
+	templ = strings.ReplaceAll(templ, "Pg", fmt.Sprintf("%0*s", 3, strconv.FormatUint(uint64(pg), 2)))
+	templ = strings.ReplaceAll(templ, "Zdn", fmt.Sprintf("%0*s", 5, strconv.FormatUint(uint64(zdn), 2)))
+	templ = strings.ReplaceAll(templ, "\t", "")
+	if code, err := strconv.ParseUint(templ, 2, 32); err != nil {
+		panic(err)
+	} else {
+		return uint32(code), 0, nil
+	}
+}
+
 func assem_prefixed_z_p_z(ins, arg_1 string, zd, pg, zn int, T string) (opcode, opcode2 uint32, err error) {
 	//
 	// insert 'MOVPRFX (predicated)' instruction
@@ -4881,6 +7354,23 @@ func assem_rr_ri(template string, rt, rt2, rn int, immPttrn string, imm int) uin
 	}
 }
 
+func assem_v_vv(template, size, Q string, vd, vn, vm int) uint32 {
+	opcode := template
+	opcode = strings.ReplaceAll(opcode, "size", size)
+	opcode = strings.ReplaceAll(opcode, "Q", Q)
+	return assem_r_rr(opcode, vd, vn, vm, 0, "", 0)
+}
+
+func assem_movi(vd int, Q, op string, imm8 int) uint32 {
+	opcode := "0	Q	op	0	1	1	1	1	0	0	0	0	0	abc	cmode	0	1	defgh	Rd"
+	opcode = strings.ReplaceAll(opcode, "Q", Q)
+	opcode = strings.ReplaceAll(opcode, "op", op)
+	opcode = strings.ReplaceAll(opcode, "abc", fmt.Sprintf("%0*s", 3, strconv.FormatUint(uint64(imm8>>5), 2)))
+	opcode = strings.ReplaceAll(opcode, "cmode", "1110")
+	opcode = strings.ReplaceAll(opcode, "defgh", fmt.Sprintf("%0*s", 5, strconv.FormatUint(uint64(imm8&31), 2)))
+	return assem_r_rr(opcode, vd, 0, 0, 0, "", 0)
+}
+
 func assem_r_rrr(template string, rd, rn, rm, ra, sf int) uint32 {
 	opcode := template
 	opcode = strings.ReplaceAll(opcode, "sf", strconv.Itoa(sf))
@@ -5098,6 +7588,12 @@ func assem_z_r(template string, zd, rn int) uint32 {
 func assem_i(template string, immPttrn string, imm int) uint32 {
 	opcode := template
 	switch immPttrn {
+	case "":
+		// ignore
+	case "CRm":
+		opcode = strings.ReplaceAll(opcode, "CRm", fmt.Sprintf("%0*s", 4, strconv.FormatUint(uint64(imm), 2)))
+	case "imm7":
+		opcode = strings.ReplaceAll(opcode, "imm7", fmt.Sprintf("%0*s", 7, strconv.FormatUint(uint64(imm), 2)))
 	case "imm16":
 		opcode = strings.ReplaceAll(opcode, "imm16", fmt.Sprintf("%0*s", 16, strconv.FormatUint(uint64(imm), 2)))
 	default:
@@ -5134,6 +7630,8 @@ func assem_r_i(template string, rd int, immPttrn string, imm int) uint32 {
 		opcode = strings.ReplaceAll(opcode, "imm16", fmt.Sprintf("%0*s", 16, strconv.FormatUint(uint64(imm), 2)))
 	case "immhi":
 		opcode = strings.ReplaceAll(opcode, "immhi", fmt.Sprintf("%0*s", 19, strconv.FormatUint(uint64(imm), 2)))
+	case "sysreg":
+		opcode = strings.ReplaceAll(opcode, "sysreg", fmt.Sprintf("%0*s", 15, strconv.FormatUint(uint64(imm), 2)))
 	default:
 		fmt.Println("Invalid immediate pattern: ", immPttrn)
 	}
@@ -5422,3 +7920,23 @@ func assem_z_pt(template string, zdn, pn int) uint32 {
 		return uint32(code)
 	}
 }
+
+// assem_za fills in an SME2 ZA array template. Register fields are suffixed
+// with their width when narrower than 5 bits (Zn4, Zm3, ...), in which case
+// the caller passes the register number already divided by the group size.
+func assem_za(template string, rv, off3, zn, zm int) uint32 {
+	opcode := template
+	opcode = strings.ReplaceAll(opcode, "Rv", fmt.Sprintf("%0*s", 2, strconv.FormatUint(uint64(rv), 2)))
+	opcode = strings.ReplaceAll(opcode, "off3", fmt.Sprintf("%0*s", 3, strconv.FormatUint(uint64(off3), 2)))
+	for _, width := range []int{4, 3} {
+		opcode = strings.ReplaceAll(opcode, fmt.Sprintf("Zn%d", width), fmt.Sprintf("%0*s", width, strconv.FormatUint(uint64(zn), 2)))
+		opcode = strings.ReplaceAll(opcode, fmt.Sprintf("Zm%d", width), fmt.Sprintf("%0*s", width, strconv.FormatUint(uint64(zm), 2)))
+	}
+	opcode = strings.ReplaceAll(opcode, "Zn", fmt.Sprintf("%0*s", 5, strconv.FormatUint(uint64(zn), 2)))
+	opcode = strings.ReplaceAll(opcode, "\t", "")
+	if code, err := strconv.ParseUint(opcode, 2, 32); err != nil {
+		panic(err)
+	} else {
+		return uint32(code)
+	}
+}
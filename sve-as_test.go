@@ -891,6 +891,849 @@ func TestSveAssembler(t *testing.T) {
 		{"    WORD $0x05b1a421 // clastb x1, p1, x1, z1.s"},
 		{"    WORD $0x05a0a000 // lasta x0, p0, z0.s"},
 		{"    WORD $0x05a1a000 // lastb x0, p0, z0.s"},
+		// SME2 ZA array (vector group) forms
+		{"    WORD $0xc1201800 // fmla za.s[w8, 0, vgx2], {z0.s, z1.s}, z0.s"},
+		{"    WORD $0xc1255945 // fmla za.s[w10, 5, vgx2], {z10.s, z11.s}, z5.s"},
+		{"    WORD $0xc12f7be7 // fmla za.s[w11, 7, vgx2], {z31.s, z0.s}, z15.s"},
+		{"    WORD $0xc1301800 // fmla za.s[w8, 0, vgx4], {z0.s-z3.s}, z0.s"},
+		{"    WORD $0xc1601800 // fmla za.d[w8, 0, vgx2], {z0.d, z1.d}, z0.d"},
+		{"    WORD $0xc1a01800 // fmla za.s[w8, 0, vgx2], {z0.s, z1.s}, {z0.s, z1.s}"},
+		{"    WORD $0xc1b45945 // fmla za.s[w10, 5, vgx2], {z10.s, z11.s}, {z20.s, z21.s}"},
+		{"    WORD $0xc1a11800 // fmla za.s[w8, 0, vgx4], {z0.s - z3.s}, {z0.s - z3.s}"},
+		{"    WORD $0xc1500000 // fmla za.s[w8, 0, vgx2], {z0.s, z1.s}, z0.s[0]"},
+		{"    WORD $0xc1554545 // fmla za.s[w10, 5, vgx2], {z10.s, z11.s}, z5.s[1]"},
+		{"    WORD $0xc1a01c10 // add za.s[w8, 0, vgx2], {z0.s, z1.s}"},
+		{"    WORD $0xc1e01c10 // add za.d[w8, 0, vgx2], {z0.d, z1.d}"},
+		{"    WORD $0xc1a11c10 // add za.s[w8, 0, vgx4], {z0.s-z3.s}"},
+		{"    WORD $0xc1201810 // add za.s[w8, 0, vgx2], {z0.s, z1.s}, z0.s"},
+		{"    WORD $0xc1a01810 // add za.s[w8, 0, vgx2], {z0.s, z1.s}, {z0.s, z1.s}"},
+		{"    WORD $0xc1201000 // fdot za.s[w8, 0, vgx2], {z0.h, z1.h}, z0.h"},
+		{"    WORD $0xc1a01000 // fdot za.s[w8, 0, vgx2], {z0.h, z1.h}, {z0.h, z1.h}"},
+		{"    WORD $0xc1201400 // sdot za.s[w8, 0, vgx2], {z0.b, z1.b}, z0.b"},
+		{"    WORD $0xc1601408 // sdot za.s[w8, 0, vgx2], {z0.h, z1.h}, z0.h"},
+		{"    WORD $0xc1601400 // sdot za.d[w8, 0, vgx2], {z0.h, z1.h}, z0.h"},
+		{"    WORD $0xc1201410 // udot za.s[w8, 0, vgx2], {z0.b, z1.b}, z0.b"},
+		{"    WORD $0xc1200c00 // fmlal za.s[w8, 0:1], z0.h, z0.h"},
+		{"    WORD $0xc1200800 // fmlal za.s[w8, 0:1, vgx2], {z0.h, z1.h}, z0.h"},
+		{"    WORD $0xc1a00800 // fmlal za.s[w8, 0:1, vgx2], {z0.h, z1.h}, {z0.h, z1.h}"},
+		{"    WORD $0xc0480001 // zero {zt0}"},
+		{"    WORD $0xe11f8000 // ldr zt0, [x0]"},
+		{"    WORD $0xe13f8000 // str zt0, [x0]"},
+		{"    WORD $0xc04c03e0 // movt x0, zt0[0]"},
+		{"    WORD $0xc04e03e0 // movt zt0[0], x0"},
+		{"    WORD $0xc0cd5155 // luti2 z21.h, zt0, z10[5]"},
+		{"    WORD $0xc0ca1000 // luti4 z0.h, zt0, z0[0]"},
+		{"    WORD $0xc0cd5155 // luti2 {z21.h}, zt0, z10[5]"},
+		{"    WORD $0xc0ca1000 // luti4 { z0.h }, zt0, z0[0]"},
+		// floating-point estimates, exponent and trigonometric acceleration
+		{"    WORD $0x658e3020 // frecpe z0.s, z1.s"},
+		{"    WORD $0x654e33e3 // frecpe z3.h, z31.h"},
+		{"    WORD $0x65cf3082 // frsqrte z2.d, z4.d"},
+		{"    WORD $0x65821820 // frecps z0.s, z1.s, z2.s"},
+		{"    WORD $0x65c71cc5 // frsqrts z5.d, z6.d, z7.d"},
+		{"    WORD $0x654a0d28 // ftsmul z8.h, z9.h, z10.h"},
+		{"    WORD $0x65ccac41 // frecpx z1.d, p3/m, z2.d"},
+		{"    WORD $0x651cac41 // flogb z1.s, p3/m, z2.s"},
+		{"    WORD $0x651aac41 // flogb z1.h, p3/m, z2.h"},
+		{"    WORD $0x651ebec1 // flogb z1.d, p7/m, z22.d"},
+		{"    WORD $0x65898d21 // fscale z1.s, p3/m, z1.s, z9.s"},
+		{"    WORD $0x04a0b841 // fexpa z1.s, z2.s"},
+		{"    WORD $0x04e0bac1 // fexpa z1.d, z22.d"},
+		{"    WORD $0x65978041 // ftmad z1.s, z1.s, z2.s, #7"},
+		{"    WORD $0x65d383e4 // ftmad z4.d, z4.d, z31.d, #3"},
+		{"    WORD $0x04a3b041 // ftssel z1.s, z2.s, z3.s"},
+		{"    WORD $0x65cc8d21 // fdivr z1.d, p3/m, z1.d, z9.d"},
+		// floating-point reductions, absolute/unordered compares and compare with zero
+		{"    WORD $0x65402440 // faddv h0, p1, z2.h"},
+		{"    WORD $0x65803fe3 // faddv s3, p7, z31.s"},
+		{"    WORD $0x65c020bf // faddv d31, p0, z5.d"},
+		{"    WORD $0x65862861 // fmaxv s1, p2, z3.s"},
+		{"    WORD $0x65c72861 // fminv d1, p2, z3.d"},
+		{"    WORD $0x6584c871 // facge p1.s, p2/z, z3.s, z4.s"},
+		{"    WORD $0x65c4e871 // facgt p1.d, p2/z, z3.d, z4.d"},
+		{"    WORD $0x6543c891 // facle p1.h, p2/z, z3.h, z4.h"},
+		{"    WORD $0x6583e891 // faclt p1.s, p2/z, z3.s, z4.s"},
+		{"    WORD $0x65c0dfef // fcmuo p15.d, p7/z, z31.d, z0.d"},
+		{"    WORD $0x65922861 // fcmeq p1.s, p2/z, z3.s, #0.0"},
+		{"    WORD $0x65d32861 // fcmne p1.d, p2/z, z3.d, #0.0"},
+		{"    WORD $0x65502861 // fcmge p1.h, p2/z, z3.h, #0.0"},
+		{"    WORD $0x65902871 // fcmgt p1.s, p2/z, z3.s, #0.0"},
+		{"    WORD $0x65912871 // fcmle p1.s, p2/z, z3.s, #0.0"},
+		{"    WORD $0x65d13fef // fcmlt p15.d, p7/z, z31.d, #0.0"},
+		// floating-point immediates
+		{"    WORD $0x25b9cf00 // fdup z0.s, #1.5"},
+		{"    WORD $0x2579d81f // fdup z31.h, #-0.125"},
+		{"    WORD $0x25f9c7e3 // fdup z3.d, #31.0"},
+		{"    WORD $0x25b9cf00 // fmov z0.s, #1.5"},
+		{"    WORD $0x25f9d001 // fmov z1.d, #-2.0"},
+		{"    WORD $0x2578c002 // fmov z2.h, #0.0"},
+		{"    WORD $0x05d0d000 // fcpy z0.d, p0/m, #-2.0"},
+		{"    WORD $0x055fca05 // fcpy z5.h, p15/m, #0.25"},
+		{"    WORD $0x05d0d000 // fmov z0.d, p0/m, #-2.0"},
+		{"    WORD $0x05934007 // fmov z7.s, p3/m, #0.0"},
+		{"    WORD $0x65588000 // fadd z0.h, p0/m, z0.h, #0.5"},
+		{"    WORD $0x65989c3f // fadd z31.s, p7/m, z31.s, #1.0"},
+		{"    WORD $0x65d98401 // fsub z1.d, p1/m, z1.d, #0.5"},
+		{"    WORD $0x659b8421 // fsubr z1.s, p1/m, z1.s, #1.0"},
+		{"    WORD $0x65da8822 // fmul z2.d, p2/m, z2.d, #2.0"},
+		{"    WORD $0x655a8802 // fmul z2.h, p2/m, z2.h, #0.5"},
+		{"    WORD $0x659e8c03 // fmax z3.s, p3/m, z3.s, #0.0"},
+		{"    WORD $0x65df8c23 // fmin z3.d, p3/m, z3.d, #1.0"},
+		{"    WORD $0x655c9024 // fmaxnm z4.h, p4/m, z4.h, #1.0"},
+		{"    WORD $0x659d9004 // fminnm z4.s, p4/m, z4.s, #0.0"},
+		// floating-point conversions
+		{"    WORD $0x6588a020 // fcvt z0.h, p0/m, z1.s"},
+		{"    WORD $0x6589a420 // fcvt z0.s, p1/m, z1.h"},
+		{"    WORD $0x65c8a862 // fcvt z2.h, p2/m, z3.d"},
+		{"    WORD $0x65c9a862 // fcvt z2.d, p2/m, z3.h"},
+		{"    WORD $0x65caaca4 // fcvt z4.s, p3/m, z5.d"},
+		{"    WORD $0x65cbbfdf // fcvt z31.d, p7/m, z30.s"},
+		{"    WORD $0x650aa440 // fcvtx z0.s, p1/m, z2.d"},
+		{"    WORD $0x6488a440 // fcvtnt z0.h, p1/m, z2.s"},
+		{"    WORD $0x64caa440 // fcvtnt z0.s, p1/m, z2.d"},
+		{"    WORD $0x6489a440 // fcvtlt z0.s, p1/m, z2.h"},
+		{"    WORD $0x64cba440 // fcvtlt z0.d, p1/m, z2.s"},
+		{"    WORD $0x640aa440 // fcvtxnt z0.s, p1/m, z2.d"},
+		{"    WORD $0x655aa020 // fcvtzs z0.h, p0/m, z1.h"},
+		{"    WORD $0x655ca020 // fcvtzs z0.s, p0/m, z1.h"},
+		{"    WORD $0x655fa020 // fcvtzu z0.d, p0/m, z1.h"},
+		{"    WORD $0x659da020 // fcvtzu z0.s, p0/m, z1.s"},
+		{"    WORD $0x65dca020 // fcvtzs z0.d, p0/m, z1.s"},
+		{"    WORD $0x65d8a020 // fcvtzs z0.s, p0/m, z1.d"},
+		{"    WORD $0x65dfa020 // fcvtzu z0.d, p0/m, z1.d"},
+		{"    WORD $0x6552a020 // scvtf z0.h, p0/m, z1.h"},
+		{"    WORD $0x6554a020 // scvtf z0.h, p0/m, z1.s"},
+		{"    WORD $0x6557a020 // ucvtf z0.h, p0/m, z1.d"},
+		{"    WORD $0x6595a020 // ucvtf z0.s, p0/m, z1.s"},
+		{"    WORD $0x65d0a020 // scvtf z0.d, p0/m, z1.s"},
+		{"    WORD $0x65d4a020 // scvtf z0.s, p0/m, z1.d"},
+		{"    WORD $0x65d7a020 // ucvtf z0.d, p0/m, z1.d"},
+		{"    WORD $0x6586a440 // frintx z0.s, p1/m, z2.s"},
+		{"    WORD $0x65c7bfe3 // frinti z3.d, p7/m, z31.d"},
+		{"    WORD $0x6546a8c5 // frintx z5.h, p2/m, z6.h"},
+		{"    WORD $0x1e780020 // fcvtzs w0, d1"},
+		{"    WORD $0x9e380083 // fcvtzs x3, s4"},
+		{"    WORD $0x1ef900c5 // fcvtzu w5, h6"},
+		{"    WORD $0x9e790107 // fcvtzu x7, d8"},
+		{"    WORD $0x9e620020 // scvtf d0, x1"},
+		{"    WORD $0x1e220062 // scvtf s2, w3"},
+		{"    WORD $0x9ee300a4 // ucvtf h4, x5"},
+		{"    WORD $0x1e6300e6 // ucvtf d6, w7"},
+		// shifts: divide, rounding, saturating, accumulate, insert and widening
+		{"    WORD $0x040481e0 // asrd z0.b, p0/m, z0.b, #1"},
+		{"    WORD $0x04048601 // asrd z1.h, p1/m, z1.h, #16"},
+		{"    WORD $0x04448b22 // asrd z2.s, p2/m, z2.s, #7"},
+		{"    WORD $0x04849c1f // asrd z31.d, p7/m, z31.d, #64"},
+		{"    WORD $0x040c8100 // srshr z0.b, p0/m, z0.b, #8"},
+		{"    WORD $0x048c87e1 // srshr z1.d, p1/m, z1.d, #33"},
+		{"    WORD $0x044d8be2 // urshr z2.s, p2/m, z2.s, #1"},
+		{"    WORD $0x040d8ee3 // urshr z3.h, p3/m, z3.h, #9"},
+		{"    WORD $0x040f8100 // sqshlu z0.b, p0/m, z0.b, #0"},
+		{"    WORD $0x04cf93e4 // sqshlu z4.d, p4/m, z4.d, #63"},
+		{"    WORD $0x044697e5 // sqshl z5.s, p5/m, z5.s, #31"},
+		{"    WORD $0x04079a66 // uqshl z6.h, p6/m, z6.h, #3"},
+		{"    WORD $0x44028020 // srshl z0.b, p0/m, z0.b, z1.b"},
+		{"    WORD $0x44438440 // urshl z0.h, p1/m, z0.h, z2.h"},
+		{"    WORD $0x44868860 // srshlr z0.s, p2/m, z0.s, z3.s"},
+		{"    WORD $0x44c78c80 // urshlr z0.d, p3/m, z0.d, z4.d"},
+		{"    WORD $0x440890a1 // sqshl z1.b, p4/m, z1.b, z5.b"},
+		{"    WORD $0x444994c1 // uqshl z1.h, p5/m, z1.h, z6.h"},
+		{"    WORD $0x448a98e1 // sqrshl z1.s, p6/m, z1.s, z7.s"},
+		{"    WORD $0x44cb9d01 // uqrshl z1.d, p7/m, z1.d, z8.d"},
+		{"    WORD $0x440c8122 // sqshlr z2.b, p0/m, z2.b, z9.b"},
+		{"    WORD $0x444d8542 // uqshlr z2.h, p1/m, z2.h, z10.h"},
+		{"    WORD $0x448e8962 // sqrshlr z2.s, p2/m, z2.s, z11.s"},
+		{"    WORD $0x44cf9fdf // uqrshlr z31.d, p7/m, z31.d, z30.d"},
+		{"    WORD $0x450fe020 // ssra z0.b, z1.b, #1"},
+		{"    WORD $0x4510e420 // usra z0.h, z1.h, #16"},
+		{"    WORD $0x4540e820 // srsra z0.s, z1.s, #32"},
+		{"    WORD $0x4580ec20 // ursra z0.d, z1.d, #64"},
+		{"    WORD $0x45dfe3df // ssra z31.d, z30.d, #1"},
+		{"    WORD $0x4508f020 // sri z0.b, z1.b, #8"},
+		{"    WORD $0x45ddf020 // sri z0.d, z1.d, #3"},
+		{"    WORD $0x4508f420 // sli z0.b, z1.b, #0"},
+		{"    WORD $0x451ff420 // sli z0.h, z1.h, #15"},
+		{"    WORD $0x45dff420 // sli z0.d, z1.d, #63"},
+		{"    WORD $0x4508a020 // sshllb z0.h, z1.b, #0"},
+		{"    WORD $0x450fa420 // sshllt z0.h, z1.b, #7"},
+		{"    WORD $0x451fa820 // ushllb z0.s, z1.h, #15"},
+		{"    WORD $0x455fac20 // ushllt z0.d, z1.s, #31"},
+		{"    WORD $0x4543a3df // sshllb z31.d, z30.s, #3"},
+		{"    WORD $0x4508a020 // sxtlb z0.h, z1.b"},
+		{"    WORD $0x4510a420 // sxtlt z0.s, z1.h"},
+		{"    WORD $0x4540a820 // uxtlb z0.d, z1.s"},
+		{"    WORD $0x4508ac20 // uxtlt z0.h, z1.b"},
+		{"    WORD $0x04008100 // asr z0.b, p0/m, z0.b, #8"},
+		// bitwise ternary select and interleaving exclusive or
+		{"    WORD $0x04213840 // eor3 z0.d, z0.d, z1.d, z2.d"},
+		{"    WORD $0x046438a3 // bcax z3.d, z3.d, z4.d, z5.d"},
+		{"    WORD $0x04213c40 // bsl z0.d, z0.d, z1.d, z2.d"},
+		{"    WORD $0x047e3fbf // bsl1n z31.d, z31.d, z30.d, z29.d"},
+		{"    WORD $0x04a83d27 // bsl2n z7.d, z7.d, z8.d, z9.d"},
+		{"    WORD $0x04eb3d8a // nbsl z10.d, z10.d, z11.d, z12.d"},
+		{"    WORD $0x45029020 // eorbt z0.b, z1.b, z2.b"},
+		{"    WORD $0x45c59083 // eorbt z3.d, z4.d, z5.d"},
+		{"    WORD $0x454894e6 // eortb z6.h, z7.h, z8.h"},
+		{"    WORD $0x459d97df // eortb z31.s, z30.s, z29.s"},
+		// SHA3, SM4, polynomial multiply long (.q) and add/subtract with carry long
+		{"    WORD $0x4522f420 // rax1 z0.d, z1.d, z2.d"},
+		{"    WORD $0x453df7df // rax1 z31.d, z30.d, z29.d"},
+		{"    WORD $0x4523e020 // sm4e z0.s, z0.s, z1.s"},
+		{"    WORD $0x4523e3df // sm4e z31.s, z31.s, z30.s"},
+		{"    WORD $0x4522f020 // sm4ekey z0.s, z1.s, z2.s"},
+		{"    WORD $0x453df3df // sm4ekey z31.s, z30.s, z29.s"},
+		{"    WORD $0x45026820 // pmullb z0.q, z1.d, z2.d"},
+		{"    WORD $0x451d6fdf // pmullt z31.q, z30.d, z29.d"},
+		{"    WORD $0x4502d020 // adclb z0.s, z1.s, z2.s"},
+		{"    WORD $0x4545d483 // adclt z3.d, z4.d, z5.d"},
+		{"    WORD $0x4588d0e6 // sbclb z6.s, z7.s, z8.s"},
+		{"    WORD $0x45ddd7df // sbclt z31.d, z30.d, z29.d"},
+		// predicated integer and floating-point data processing
+		{"    WORD $0x04024020 // mla z0.b, p0/m, z1.b, z2.b"},
+		{"    WORD $0x04dd5fdf // mla z31.d, p7/m, z30.d, z29.d"},
+		{"    WORD $0x04856483 // mls z3.s, p1/m, z4.s, z5.s"},
+		{"    WORD $0x44220820 // mla z0.h, z1.h, z2.h[0]"},
+		{"    WORD $0x447f0820 // mla z0.h, z1.h, z7.h[7]"},
+		{"    WORD $0x44bf0820 // mla z0.s, z1.s, z7.s[3]"},
+		{"    WORD $0x44ff0820 // mla z0.d, z1.d, z15.d[1]"},
+		{"    WORD $0x446b0cc5 // mls z5.h, z6.h, z3.h[5]"},
+		{"    WORD $0x44b30cc5 // mls z5.s, z6.s, z3.s[2]"},
+		{"    WORD $0x44e90cc5 // mls z5.d, z6.d, z9.d[0]"},
+		{"    WORD $0x0401c040 // mad z0.b, p0/m, z1.b, z2.b"},
+		{"    WORD $0x0441e440 // msb z0.h, p1/m, z1.h, z2.h"},
+		{"    WORD $0x04deffbf // msb z31.d, p7/m, z30.d, z29.d"},
+		{"    WORD $0x04950020 // udiv z0.s, p0/m, z0.s, z1.s"},
+		{"    WORD $0x04d51fdf // udiv z31.d, p7/m, z31.d, z30.d"},
+		{"    WORD $0x04970862 // udivr z2.s, p2/m, z2.s, z3.s"},
+		{"    WORD $0x04d70ca4 // udivr z4.d, p3/m, z4.d, z5.d"},
+		{"    WORD $0x0450a020 // sxtb z0.h, p0/m, z1.h"},
+		{"    WORD $0x04d0a020 // sxtb z0.d, p0/m, z1.d"},
+		{"    WORD $0x0491a420 // uxtb z0.s, p1/m, z1.s"},
+		{"    WORD $0x0492a820 // sxth z0.s, p2/m, z1.s"},
+		{"    WORD $0x04d3ac20 // uxth z0.d, p3/m, z1.d"},
+		{"    WORD $0x04d4b020 // sxtw z0.d, p4/m, z1.d"},
+		{"    WORD $0x04d5bfdf // uxtw z31.d, p7/m, z30.d"},
+		{"    WORD $0x4502f820 // saba z0.b, z1.b, z2.b"},
+		{"    WORD $0x4542fc20 // uaba z0.h, z1.h, z2.h"},
+		{"    WORD $0x4582f820 // saba z0.s, z1.s, z2.s"},
+		{"    WORD $0x45ddffdf // uaba z31.d, z30.d, z29.d"},
+		{"    WORD $0x65488020 // fabd z0.h, p0/m, z0.h, z1.h"},
+		{"    WORD $0x65888420 // fabd z0.s, p1/m, z0.s, z1.s"},
+		{"    WORD $0x65c48862 // fmaxnm z2.d, p2/m, z2.d, z3.d"},
+		{"    WORD $0x65858c62 // fminnm z2.s, p3/m, z2.s, z3.s"},
+		{"    WORD $0x654a90a4 // fmulx z4.h, p4/m, z4.h, z5.h"},
+		{"    WORD $0x65ca9fdf // fmulx z31.d, p7/m, z31.d, z30.d"},
+		{"    WORD $0x658394e6 // fsubr z6.s, p5/m, z6.s, z7.s"},
+		{"    WORD $0x65a26020 // fnmls z0.s, p0/m, z1.s, z2.s"},
+		{"    WORD $0x65fd7fdf // fnmls z31.d, p7/m, z30.d, z29.d"},
+		{"    WORD $0x65628420 // fmad z0.h, p1/m, z1.h, z2.h"},
+		{"    WORD $0x65a2a420 // fmsb z0.s, p1/m, z1.s, z2.s"},
+		{"    WORD $0x65e2c420 // fnmad z0.d, p1/m, z1.d, z2.d"},
+		{"    WORD $0x6565e883 // fnmsb z3.h, p2/m, z4.h, z5.h"},
+		{"    WORD $0x93407c20 // sxtw x0, w1"},
+		{"    WORD $0x53001c20 // uxtb w0, w1"},
+		// quadword permutes, two-register table lookup and 128-bit element zip/uzp/trn
+		{"    WORD $0x05a20020 // zip1 z0.q, z1.q, z2.q"},
+		{"    WORD $0x05bd07df // zip2 z31.q, z30.q, z29.q"},
+		{"    WORD $0x05a50883 // uzp1 z3.q, z4.q, z5.q"},
+		{"    WORD $0x05a80ce6 // uzp2 z6.q, z7.q, z8.q"},
+		{"    WORD $0x05ab1949 // trn1 z9.q, z10.q, z11.q"},
+		{"    WORD $0x05ae1dac // trn2 z12.q, z13.q, z14.q"},
+		{"    WORD $0x052e8020 // revd z0.q, p0/m, z1.q"},
+		{"    WORD $0x052e9fdf // revd z31.q, p7/m, z30.q"},
+		{"    WORD $0x05232820 // tbl z0.b, { z1.b, z2.b }, z3.b"},
+		{"    WORD $0x05e42bdf // tbl z31.d, { z30.d, z31.d }, z4.d"},
+		{"    WORD $0x05222400 // dupq z0.h, z0.h[0]"},
+		{"    WORD $0x053f27ff // dupq z31.b, z31.b[15]"},
+		{"    WORD $0x053c2441 // dupq z1.s, z2.s[3]"},
+		{"    WORD $0x05382441 // dupq z1.d, z2.d[1]"},
+		{"    WORD $0x05602400 // extq z0.b, z0.b, z0.b, #0"},
+		{"    WORD $0x056825b7 // extq z23.b, z23.b, z13.b, #8"},
+		{"    WORD $0x4400e000 // zipq1 z0.b, z0.b, z0.b"},
+		{"    WORD $0x4442e420 // zipq2 z0.h, z1.h, z2.h"},
+		{"    WORD $0x4482e820 // uzpq1 z0.s, z1.s, z2.s"},
+		{"    WORD $0x44dfefff // uzpq2 z31.d, z31.d, z31.d"},
+		{"    WORD $0x4400f800 // tblq z0.b, { z0.b }, z0.b"},
+		{"    WORD $0x44ddfbdf // tblq z31.d, { z30.d }, z29.d"},
+		{"    WORD $0x05203400 // tbxq z0.b, z0.b, z0.b"},
+		{"    WORD $0x05a734c5 // tbxq z5.s, z6.s, z7.s"},
+		// load-acquire/store-release, exclusives and LSE atomics
+		{"    WORD $0x88dffc20 // ldar w0, [x1]"},
+		{"    WORD $0xc8dfffe2 // ldar x2, [sp]"},
+		{"    WORD $0x08dffc83 // ldarb w3, [x4]"},
+		{"    WORD $0x48dffcc5 // ldarh w5, [x6]"},
+		{"    WORD $0xb8bfc020 // ldapr w0, [x1]"},
+		{"    WORD $0xf8bfc062 // ldapr x2, [x3]"},
+		{"    WORD $0x38bfc0a4 // ldaprb w4, [x5]"},
+		{"    WORD $0x78bfc0e6 // ldaprh w6, [x7]"},
+		{"    WORD $0x889ffc20 // stlr w0, [x1]"},
+		{"    WORD $0xc89ffc62 // stlr x2, [x3]"},
+		{"    WORD $0x089ffca4 // stlrb w4, [x5]"},
+		{"    WORD $0x489ffce6 // stlrh w6, [x7, #0]"},
+		{"    WORD $0x885f7c20 // ldxr w0, [x1]"},
+		{"    WORD $0xc85f7c62 // ldxr x2, [x3]"},
+		{"    WORD $0x085f7ca4 // ldxrb w4, [x5]"},
+		{"    WORD $0x485f7ce6 // ldxrh w6, [x7]"},
+		{"    WORD $0x885ffc20 // ldaxr w0, [x1]"},
+		{"    WORD $0xc85ffc62 // ldaxr x2, [x3]"},
+		{"    WORD $0x085ffca4 // ldaxrb w4, [x5]"},
+		{"    WORD $0x485ffce6 // ldaxrh w6, [x7]"},
+		{"    WORD $0x88007c41 // stxr w0, w1, [x2]"},
+		{"    WORD $0xc8037ca4 // stxr w3, x4, [x5]"},
+		{"    WORD $0x08067d07 // stxrb w6, w7, [x8]"},
+		{"    WORD $0x48097d6a // stxrh w9, w10, [x11]"},
+		{"    WORD $0xc8017fe0 // stxr w1, x0, [sp]"},
+		{"    WORD $0x8800fc41 // stlxr w0, w1, [x2]"},
+		{"    WORD $0xc803ffe4 // stlxr w3, x4, [sp]"},
+		{"    WORD $0x0806fd07 // stlxrb w6, w7, [x8]"},
+		{"    WORD $0x4809fd6a // stlxrh w9, w10, [x11]"},
+		{"    WORD $0x887f0440 // ldxp w0, w1, [x2]"},
+		{"    WORD $0xc87f10a3 // ldxp x3, x4, [x5]"},
+		{"    WORD $0x887f8440 // ldaxp w0, w1, [x2]"},
+		{"    WORD $0xc87f90a3 // ldaxp x3, x4, [x5]"},
+		{"    WORD $0x88200861 // stxp w0, w1, w2, [x3]"},
+		{"    WORD $0xc82418e5 // stxp w4, x5, x6, [x7]"},
+		{"    WORD $0x88208861 // stlxp w0, w1, w2, [x3]"},
+		{"    WORD $0xc82498e5 // stlxp w4, x5, x6, [x7]"},
+		{"    WORD $0xb8200041 // ldadd w0, w1, [x2]"},
+		{"    WORD $0xf8a00041 // ldadda x0, x1, [x2]"},
+		{"    WORD $0xb8e003e1 // ldaddal w0, w1, [sp]"},
+		{"    WORD $0xf86300a4 // ldaddl x3, x4, [x5]"},
+		{"    WORD $0x38200041 // ldaddb w0, w1, [x2]"},
+		{"    WORD $0x78e00041 // ldaddalh w0, w1, [x2]"},
+		{"    WORD $0xf8201041 // ldclr x0, x1, [x2]"},
+		{"    WORD $0xb8e01041 // ldclral w0, w1, [x2]"},
+		{"    WORD $0xb8202041 // ldeor w0, w1, [x2]"},
+		{"    WORD $0x38602041 // ldeorlb w0, w1, [x2]"},
+		{"    WORD $0xf8203041 // ldset x0, x1, [x2]"},
+		{"    WORD $0x78a03041 // ldsetah w0, w1, [x2]"},
+		{"    WORD $0xb8204041 // ldsmax w0, w1, [x2]"},
+		{"    WORD $0xf8e04041 // ldsmaxal x0, x1, [x2]"},
+		{"    WORD $0xb8205041 // ldsmin w0, w1, [x2]"},
+		{"    WORD $0x38205041 // ldsminb w0, w1, [x2]"},
+		{"    WORD $0xf8206041 // ldumax x0, x1, [x2]"},
+		{"    WORD $0xb8606041 // ldumaxl w0, w1, [x2]"},
+		{"    WORD $0xb8207041 // ldumin w0, w1, [x2]"},
+		{"    WORD $0x78e07041 // lduminalh w0, w1, [x2]"},
+		{"    WORD $0xb8208041 // swp w0, w1, [x2]"},
+		{"    WORD $0xf8a08041 // swpa x0, x1, [x2]"},
+		{"    WORD $0xb8e08041 // swpal w0, w1, [x2]"},
+		{"    WORD $0xf8608041 // swpl x0, x1, [x2]"},
+		{"    WORD $0x38208041 // swpb w0, w1, [x2]"},
+		{"    WORD $0x78e08041 // swpalh w0, w1, [x2]"},
+		{"    WORD $0xb820003f // stadd w0, [x1]"},
+		{"    WORD $0xf860003f // staddl x0, [x1]"},
+		{"    WORD $0x3820003f // staddb w0, [x1]"},
+		{"    WORD $0x7860003f // staddlh w0, [x1]"},
+		{"    WORD $0xf820103f // stclr x0, [x1]"},
+		{"    WORD $0xb82023ff // steor w0, [sp]"},
+		{"    WORD $0xb820303f // stset w0, [x1]"},
+		{"    WORD $0xf820403f // stsmax x0, [x1]"},
+		{"    WORD $0xb820503f // stsmin w0, [x1]"},
+		{"    WORD $0xb820603f // stumax w0, [x1]"},
+		{"    WORD $0xf860703f // stuminl x0, [x1]"},
+		// scalar multiply-long, crc32 and conditional compare
+		{"    WORD $0x9bc27c20 // umulh x0, x1, x2"},
+		{"    WORD $0x9b5f7c83 // smulh x3, x4, xzr"},
+		{"    WORD $0x04d30020 // umulh z0.d, p0/m, z0.d, z1.d"},
+		{"    WORD $0x04130462 // umulh z2.b, p1/m, z2.b, z3.b"},
+		{"    WORD $0x9b220c20 // smaddl x0, w1, w2, x3"},
+		{"    WORD $0x9ba61ca4 // umaddl x4, w5, w6, x7"},
+		{"    WORD $0x9b228c20 // smsubl x0, w1, w2, x3"},
+		{"    WORD $0x9ba6fca4 // umsubl x4, w5, w6, xzr"},
+		{"    WORD $0x9b227c20 // smull x0, w1, w2"},
+		{"    WORD $0x9ba57c83 // umull x3, w4, w5"},
+		{"    WORD $0x9b22fc20 // smnegl x0, w1, w2"},
+		{"    WORD $0x9ba5fc83 // umnegl x3, w4, w5"},
+		{"    WORD $0x1ac24020 // crc32b w0, w1, w2"},
+		{"    WORD $0x1ac24420 // crc32h w0, w1, w2"},
+		{"    WORD $0x1ac24820 // crc32w w0, w1, w2"},
+		{"    WORD $0x9ac24c20 // crc32x w0, w1, x2"},
+		{"    WORD $0x1ac55083 // crc32cb w3, w4, w5"},
+		{"    WORD $0x1ac55483 // crc32ch w3, w4, w5"},
+		{"    WORD $0x1ac55883 // crc32cw w3, w4, w5"},
+		{"    WORD $0x9ac55c83 // crc32cx w3, w4, x5"},
+		{"    WORD $0xfa411004 // ccmp x0, x1, #4, ne"},
+		{"    WORD $0x7a43004f // ccmp w2, w3, #15, eq"},
+		{"    WORD $0xba41b000 // ccmn x0, x1, #0, lt"},
+		{"    WORD $0xfa5f2802 // ccmp x0, #31, #2, hs"},
+		{"    WORD $0x3a45c888 // ccmn w4, #5, #8, gt"},
+		{"    WORD $0x9ac20c20 // sdiv x0, x1, x2"},
+		{"    WORD $0x1ac50c83 // sdiv w3, w4, w5"},
+		{"    WORD $0x1ac50883 // udiv w3, w4, w5"},
+		{"    WORD $0xdac01020 // clz x0, x1"},
+		{"    WORD $0x5ac01062 // clz w2, w3"},
+		{"    WORD $0xdac014a4 // cls x4, x5"},
+		{"    WORD $0x5ac014e6 // cls w6, w7"},
+		{"    WORD $0x9a9f37e0 // cset x0, hs"},
+		{"    WORD $0x1a823020 // csel w0, w1, w2, lo"},
+		// barriers, system registers, cache maintenance and hints
+		{"    WORD $0xd5033bbf // dmb ish"},
+		{"    WORD $0xd50339bf // dmb ishld"},
+		{"    WORD $0xd5033fbf // dmb sy"},
+		{"    WORD $0xd50332bf // dmb oshst"},
+		{"    WORD $0xd5033f9f // dsb sy"},
+		{"    WORD $0xd503379f // dsb nsh"},
+		{"    WORD $0xd5033c9f // dsb #12"},
+		{"    WORD $0xd5033fdf // isb"},
+		{"    WORD $0xd5033fdf // isb sy"},
+		{"    WORD $0xd50335df // isb #5"},
+		{"    WORD $0xd53be040 // mrs x0, cntvct_el0"},
+		{"    WORD $0xd53be001 // mrs x1, cntfrq_el0"},
+		{"    WORD $0xd53b4402 // mrs x2, fpcr"},
+		{"    WORD $0xd53b4423 // mrs x3, fpsr"},
+		{"    WORD $0xd53b00e4 // mrs x4, dczid_el0"},
+		{"    WORD $0xd53b4245 // mrs x5, svcr"},
+		{"    WORD $0xd53bd046 // mrs x6, tpidr_el0"},
+		{"    WORD $0xd53b4207 // mrs x7, nzcv"},
+		{"    WORD $0xd5380008 // mrs x8, midr_el1"},
+		{"    WORD $0xd5380489 // mrs x9, id_aa64zfr0_el1"},
+		{"    WORD $0xd53804aa // mrs x10, id_aa64smfr0_el1"},
+		{"    WORD $0xd53be04b // mrs x11, s3_3_c14_c0_2"},
+		{"    WORD $0xd51b4400 // msr fpcr, x0"},
+		{"    WORD $0xd51b4421 // msr fpsr, x1"},
+		{"    WORD $0xd51b4202 // msr nzcv, x2"},
+		{"    WORD $0xd51bd0a3 // msr tpidr2_el0, x3"},
+		{"    WORD $0xd51b441f // msr s3_3_c4_c4_0, xzr"},
+		{"    WORD $0xd50b7420 // dc zva, x0"},
+		{"    WORD $0xd50b7e21 // dc civac, x1"},
+		{"    WORD $0xd50b7a22 // dc cvac, x2"},
+		{"    WORD $0xd50b7b23 // dc cvau, x3"},
+		{"    WORD $0xd50b7c24 // dc cvap, x4"},
+		{"    WORD $0xd5087625 // dc ivac, x5"},
+		{"    WORD $0xd503201f // hint #0"},
+		{"    WORD $0xd503245f // hint #34"},
+		{"    WORD $0xd5032fff // hint #127"},
+		{"    WORD $0xd503203f // yield"},
+		{"    WORD $0xd503205f // wfe"},
+		{"    WORD $0xd503207f // wfi"},
+		{"    WORD $0xd503209f // sev"},
+		{"    WORD $0xd50320bf // sevl"},
+		{"    WORD $0xd503241f // bti"},
+		{"    WORD $0xd503245f // bti c"},
+		{"    WORD $0xd503249f // bti j"},
+		{"    WORD $0xd50324df // bti jc"},
+		{"    WORD $0xd50330ff // sb"},
+		// register pairs (incl. non-temporal and SIMD&FP) and SIMD&FP ldr/str
+		{"    WORD $0xa9400440 // ldp x0, x1, [x2]"},
+		{"    WORD $0x29410440 // ldp w0, w1, [x2, #8]"},
+		{"    WORD $0x29be13e3 // stp w3, w4, [sp, #-16]!"},
+		{"    WORD $0x28c213e3 // ldp w3, w4, [sp], #16"},
+		{"    WORD $0xa8c17bfd // ldp x29, x30, [sp], #16"},
+		{"    WORD $0xa9be7bfd // stp x29, x30, [sp, #-32]!"},
+		{"    WORD $0xa95f8440 // ldp x0, x1, [x2, #504]"},
+		{"    WORD $0xa9600440 // ldp x0, x1, [x2, #-512]"},
+		{"    WORD $0x295f8440 // ldp w0, w1, [x2, #252]"},
+		{"    WORD $0xa8400440 // ldnp x0, x1, [x2]"},
+		{"    WORD $0xa87f0440 // ldnp x0, x1, [x2, #-16]"},
+		{"    WORD $0x28008440 // stnp w0, w1, [x2, #4]"},
+		{"    WORD $0xac410440 // ldnp q0, q1, [x2, #32]"},
+		{"    WORD $0x6c3f8440 // stnp d0, d1, [x2, #-8]"},
+		{"    WORD $0x2c400440 // ldnp s0, s1, [x2]"},
+		{"    WORD $0x69400440 // ldpsw x0, x1, [x2]"},
+		{"    WORD $0x69ff0440 // ldpsw x0, x1, [x2, #-8]!"},
+		{"    WORD $0x68df8440 // ldpsw x0, x1, [x2], #252"},
+		{"    WORD $0xad400400 // ldp q0, q1, [x0]"},
+		{"    WORD $0xad010400 // stp q0, q1, [x0, #32]"},
+		{"    WORD $0xade00fe2 // ldp q2, q3, [sp, #-1024]!"},
+		{"    WORD $0xac9f8fe2 // stp q2, q3, [sp], #1008"},
+		{"    WORD $0x6dff27e8 // ldp d8, d9, [sp, #-16]!"},
+		{"    WORD $0x6dbf27e8 // stp d8, d9, [sp, #-16]!"},
+		{"    WORD $0x6d412fea // ldp d10, d11, [sp, #16]"},
+		{"    WORD $0x6cc43fee // ldp d14, d15, [sp], #64"},
+		{"    WORD $0x2d408420 // ldp s0, s1, [x1, #4]"},
+		{"    WORD $0x2ca00420 // stp s0, s1, [x1], #-256"},
+		{"    WORD $0x3dc00000 // ldr q0, [x0]"},
+		{"    WORD $0x3dc00401 // ldr q1, [x0, #16]"},
+		{"    WORD $0x3dfffc01 // ldr q1, [x0, #65520]"},
+		{"    WORD $0x3cc10c02 // ldr q2, [x0, #16]!"},
+		{"    WORD $0x3cdf0403 // ldr q3, [x0], #-16"},
+		{"    WORD $0x3d800000 // str q0, [x0]"},
+		{"    WORD $0x3d800fe1 // str q1, [sp, #48]"},
+		{"    WORD $0x3c900c02 // str q2, [x0, #-256]!"},
+		{"    WORD $0x3c8ff403 // str q3, [x0], #255"},
+		{"    WORD $0xfd400420 // ldr d0, [x1, #8]"},
+		{"    WORD $0xfc008420 // str d0, [x1], #8"},
+		{"    WORD $0xbd400420 // ldr s0, [x1, #4]"},
+		{"    WORD $0xbc1fcc21 // str s1, [x1, #-4]!"},
+		{"    WORD $0x7d400420 // ldr h0, [x1, #2]"},
+		{"    WORD $0x7c002420 // str h0, [x1], #2"},
+		{"    WORD $0x3d400420 // ldr b0, [x1, #1]"},
+		{"    WORD $0x3d3ffc20 // str b0, [x1, #4095]"},
+		// scalar load/store addressing matrix (literal, register offset with extend, auto-selected ldur)
+		{"    WORD $0xb9400020 // ldr w0, [x1]"},
+		{"    WORD $0xb9400420 // ldr w0, [x1, #4]"},
+		{"    WORD $0xb97ffc20 // ldr w0, [x1, #16380]"},
+		{"    WORD $0xb85fc020 // ldr w0, [x1, #-4]"},
+		{"    WORD $0xb8403020 // ldr w0, [x1, #3]"},
+		{"    WORD $0xf8500020 // ldr x0, [x1, #-256]"},
+		{"    WORD $0xf84ff020 // ldr x0, [x1, #255]"},
+		{"    WORD $0xf840c020 // ldr x0, [x1, #12]"},
+		{"    WORD $0xf85f8fe0 // ldr x0, [sp, #-8]!"},
+		{"    WORD $0xb85fc420 // ldr w0, [x1], #-4"},
+		{"    WORD $0xf8624820 // ldr x0, [x1, w2, uxtw]"},
+		{"    WORD $0xf8625820 // ldr x0, [x1, w2, uxtw #3]"},
+		{"    WORD $0xf862c820 // ldr x0, [x1, w2, sxtw]"},
+		{"    WORD $0xf862d820 // ldr x0, [x1, w2, sxtw #3]"},
+		{"    WORD $0xf862e820 // ldr x0, [x1, x2, sxtx]"},
+		{"    WORD $0xf862f820 // ldr x0, [x1, x2, sxtx #3]"},
+		{"    WORD $0xf8626820 // ldr x0, [x1, x2, lsl #0]"},
+		{"    WORD $0xb8627820 // ldr w0, [x1, x2, lsl #2]"},
+		{"    WORD $0xb862d820 // ldr w0, [x1, w2, sxtw #2]"},
+		{"    WORD $0x58000040 // ldr x0, #8"},
+		{"    WORD $0x58ffffe0 // ldr x0, #-4"},
+		{"    WORD $0x187fffe1 // ldr w1, #1048572"},
+		{"    WORD $0x18800001 // ldr w1, #-1048576"},
+		{"    WORD $0x98000080 // ldrsw x0, #16"},
+		{"    WORD $0x1c000020 // ldr s0, #4"},
+		{"    WORD $0x5cffffc0 // ldr d0, #-8"},
+		{"    WORD $0x9c000200 // ldr q0, #64"},
+		{"    WORD $0xb81fc020 // str w0, [x1, #-4]"},
+		{"    WORD $0xf8007020 // str x0, [x1, #7]"},
+		{"    WORD $0xb822d820 // str w0, [x1, w2, sxtw #2]"},
+		{"    WORD $0xf8226820 // str x0, [x1, x2]"},
+		{"    WORD $0xb8004420 // str w0, [x1], #4"},
+		{"    WORD $0x39400020 // ldrb w0, [x1]"},
+		{"    WORD $0x397ffc20 // ldrb w0, [x1, #4095]"},
+		{"    WORD $0x385ff020 // ldrb w0, [x1, #-1]"},
+		{"    WORD $0x38624820 // ldrb w0, [x1, w2, uxtw]"},
+		{"    WORD $0x3862d820 // ldrb w0, [x1, w2, sxtw #0]"},
+		{"    WORD $0x38626820 // ldrb w0, [x1, x2]"},
+		{"    WORD $0x38627820 // ldrb w0, [x1, x2, lsl #0]"},
+		{"    WORD $0x38401420 // ldrb w0, [x1], #1"},
+		{"    WORD $0x39c00020 // ldrsb w0, [x1]"},
+		{"    WORD $0x39800420 // ldrsb x0, [x1, #1]"},
+		{"    WORD $0x38dffc20 // ldrsb w0, [x1, #-1]!"},
+		{"    WORD $0x38a26820 // ldrsb x0, [x1, x2]"},
+		{"    WORD $0x38e2c820 // ldrsb w0, [x1, w2, sxtw]"},
+		{"    WORD $0x381ff020 // strb w0, [x1, #-1]"},
+		{"    WORD $0x38226820 // strb w0, [x1, x2]"},
+		{"    WORD $0x38100420 // strb w0, [x1], #-256"},
+		{"    WORD $0x79400420 // ldrh w0, [x1, #2]"},
+		{"    WORD $0x78403020 // ldrh w0, [x1, #3]"},
+		{"    WORD $0x785fe020 // ldrh w0, [x1, #-2]"},
+		{"    WORD $0x78627820 // ldrh w0, [x1, x2, lsl #1]"},
+		{"    WORD $0x78625820 // ldrh w0, [x1, w2, uxtw #1]"},
+		{"    WORD $0x79fffc20 // ldrsh w0, [x1, #8190]"},
+		{"    WORD $0x789fe020 // ldrsh x0, [x1, #-2]"},
+		{"    WORD $0x78a27820 // ldrsh x0, [x1, x2, lsl #1]"},
+		{"    WORD $0x78c02420 // ldrsh w0, [x1], #2"},
+		{"    WORD $0x78001020 // strh w0, [x1, #1]"},
+		{"    WORD $0x78227820 // strh w0, [x1, x2, lsl #1]"},
+		{"    WORD $0xb9800020 // ldrsw x0, [x1]"},
+		{"    WORD $0xb9bffc20 // ldrsw x0, [x1, #16380]"},
+		{"    WORD $0xb89fc020 // ldrsw x0, [x1, #-4]"},
+		{"    WORD $0xb8802020 // ldrsw x0, [x1, #2]"},
+		{"    WORD $0xb8a27820 // ldrsw x0, [x1, x2, lsl #2]"},
+		{"    WORD $0xb8a2c820 // ldrsw x0, [x1, w2, sxtw]"},
+		{"    WORD $0xb8804420 // ldrsw x0, [x1], #4"},
+		{"    WORD $0xb85fc020 // ldur w0, [x1, #-4]"},
+		{"    WORD $0xf8408020 // ldur x0, [x1, #8]"},
+		{"    WORD $0xb8400020 // ldur w0, [x1]"},
+		{"    WORD $0xb81ff020 // stur w0, [x1, #-1]"},
+		{"    WORD $0xf81f83fd // stur x29, [sp, #-8]"},
+		{"    WORD $0x385ff020 // ldurb w0, [x1, #-1]"},
+		{"    WORD $0x38dff020 // ldursb w0, [x1, #-1]"},
+		{"    WORD $0x388ff020 // ldursb x0, [x1, #255]"},
+		{"    WORD $0x78401020 // ldurh w0, [x1, #1]"},
+		{"    WORD $0x78dfe020 // ldursh w0, [x1, #-2]"},
+		{"    WORD $0x78803020 // ldursh x0, [x1, #3]"},
+		{"    WORD $0xb89fc020 // ldursw x0, [x1, #-4]"},
+		{"    WORD $0x38003020 // sturb w0, [x1, #3]"},
+		{"    WORD $0x78100020 // sturh w0, [x1, #-256]"},
+		{"    WORD $0x3c9f0020 // stur q0, [x1, #-16]"},
+		{"    WORD $0xfc401020 // ldur d0, [x1, #1]"},
+		{"    WORD $0xbc5fc020 // ldur s0, [x1, #-4]"},
+		{"    WORD $0x3cdf0020 // ldr q0, [x1, #-16]"},
+		{"    WORD $0x3cc08020 // ldr q0, [x1, #8]"},
+		{"    WORD $0xfc627820 // ldr d0, [x1, x2, lsl #3]"},
+		{"    WORD $0x3ce27820 // ldr q0, [x1, x2, lsl #4]"},
+		{"    WORD $0xbc22d820 // str s0, [x1, w2, sxtw #2]"},
+		{"    WORD $0x7c625820 // ldr h0, [x1, w2, uxtw #1]"},
+		{"    WORD $0x3c626820 // ldr b0, [x1, x2]"},
+		{"    WORD $0x3c227820 // str b0, [x1, x2, lsl #0]"},
+		// Advanced SIMD (NEON)
+		{"    WORD $0x4e228420 // add v0.16b, v1.16b, v2.16b"},
+		{"    WORD $0x4ee28420 // add v0.2d, v1.2d, v2.2d"},
+		{"    WORD $0x4ea58483 // add v3.4s, v4.4s, v5.4s"},
+		{"    WORD $0x6e628420 // sub v0.8h, v1.8h, v2.8h"},
+		{"    WORD $0x4ea29c20 // mul v0.4s, v1.4s, v2.4s"},
+		{"    WORD $0x0e229420 // mla v0.8b, v1.8b, v2.8b"},
+		{"    WORD $0x2e629420 // mls v0.4h, v1.4h, v2.4h"},
+		{"    WORD $0x6e228c20 // cmeq v0.16b, v1.16b, v2.16b"},
+		{"    WORD $0x6ee28c20 // cmeq v0.2d, v1.2d, v2.2d"},
+		{"    WORD $0x4ea28c20 // cmtst v0.4s, v1.4s, v2.4s"},
+		{"    WORD $0x4e623420 // cmgt v0.8h, v1.8h, v2.8h"},
+		{"    WORD $0x0ea23c20 // cmge v0.2s, v1.2s, v2.2s"},
+		{"    WORD $0x6e223420 // cmhi v0.16b, v1.16b, v2.16b"},
+		{"    WORD $0x6ea23c20 // cmhs v0.4s, v1.4s, v2.4s"},
+		{"    WORD $0x4e209820 // cmeq v0.16b, v1.16b, #0"},
+		{"    WORD $0x4ea08820 // cmgt v0.4s, v1.4s, #0"},
+		{"    WORD $0x6ee08820 // cmge v0.2d, v1.2d, #0"},
+		{"    WORD $0x6e609820 // cmle v0.8h, v1.8h, #0"},
+		{"    WORD $0x0e20a820 // cmlt v0.8b, v1.8b, #0"},
+		{"    WORD $0x4ea26420 // smax v0.4s, v1.4s, v2.4s"},
+		{"    WORD $0x6e226420 // umax v0.16b, v1.16b, v2.16b"},
+		{"    WORD $0x4e626c20 // smin v0.8h, v1.8h, v2.8h"},
+		{"    WORD $0x2ea26c20 // umin v0.2s, v1.2s, v2.2s"},
+		{"    WORD $0x4ea2bc20 // addp v0.4s, v1.4s, v2.4s"},
+		{"    WORD $0x4ee2bc20 // addp v0.2d, v1.2d, v2.2d"},
+		{"    WORD $0x6e22a420 // umaxp v0.16b, v1.16b, v2.16b"},
+		{"    WORD $0x6e62ac20 // uminp v0.8h, v1.8h, v2.8h"},
+		{"    WORD $0x4ea2a420 // smaxp v0.4s, v1.4s, v2.4s"},
+		{"    WORD $0x0e22ac20 // sminp v0.8b, v1.8b, v2.8b"},
+		{"    WORD $0x4ea27420 // sabd v0.4s, v1.4s, v2.4s"},
+		{"    WORD $0x6e227420 // uabd v0.16b, v1.16b, v2.16b"},
+		{"    WORD $0x4ee20c20 // sqadd v0.2d, v1.2d, v2.2d"},
+		{"    WORD $0x6e220c20 // uqadd v0.16b, v1.16b, v2.16b"},
+		{"    WORD $0x4ea22c20 // sqsub v0.4s, v1.4s, v2.4s"},
+		{"    WORD $0x6e622c20 // uqsub v0.8h, v1.8h, v2.8h"},
+		{"    WORD $0x4ee24420 // sshl v0.2d, v1.2d, v2.2d"},
+		{"    WORD $0x6ea24420 // ushl v0.4s, v1.4s, v2.4s"},
+		{"    WORD $0x4e220420 // shadd v0.16b, v1.16b, v2.16b"},
+		{"    WORD $0x6ea20420 // uhadd v0.4s, v1.4s, v2.4s"},
+		{"    WORD $0x4e621420 // srhadd v0.8h, v1.8h, v2.8h"},
+		{"    WORD $0x2e221420 // urhadd v0.8b, v1.8b, v2.8b"},
+		{"    WORD $0x4fa28820 // mul v0.4s, v1.4s, v2.s[3]"},
+		{"    WORD $0x4f7f8820 // mul v0.8h, v1.8h, v15.h[7]"},
+		{"    WORD $0x6fbf0020 // mla v0.4s, v1.4s, v31.s[1]"},
+		{"    WORD $0x2f624020 // mls v0.4h, v1.4h, v2.h[2]"},
+		{"    WORD $0x4e221c20 // and v0.16b, v1.16b, v2.16b"},
+		{"    WORD $0x0e621c20 // bic v0.8b, v1.8b, v2.8b"},
+		{"    WORD $0x4ea21c20 // orr v0.16b, v1.16b, v2.16b"},
+		{"    WORD $0x4ee21c20 // orn v0.16b, v1.16b, v2.16b"},
+		{"    WORD $0x6e221c20 // eor v0.16b, v1.16b, v2.16b"},
+		{"    WORD $0x6e621c20 // bsl v0.16b, v1.16b, v2.16b"},
+		{"    WORD $0x2ea21c20 // bit v0.8b, v1.8b, v2.8b"},
+		{"    WORD $0x6ee21c20 // bif v0.16b, v1.16b, v2.16b"},
+		{"    WORD $0x4ea11c20 // mov v0.16b, v1.16b"},
+		{"    WORD $0x0ebf1fe0 // mov v0.8b, v31.8b"},
+		{"    WORD $0x4e0c1c20 // mov v0.s[1], w1"},
+		{"    WORD $0x4e181c20 // mov v0.d[1], x1"},
+		{"    WORD $0x4e1f1c40 // mov v0.b[15], w2"},
+		{"    WORD $0x6e0e7420 // mov v0.h[3], v1.h[7]"},
+		{"    WORD $0x6e046420 // mov v0.s[0], v1.s[3]"},
+		{"    WORD $0x0e0c3c20 // mov w0, v1.s[1]"},
+		{"    WORD $0x4e183c20 // mov x0, v1.d[1]"},
+		{"    WORD $0x0e1f3c20 // umov w0, v1.b[15]"},
+		{"    WORD $0x0e1e3c20 // umov w0, v1.h[7]"},
+		{"    WORD $0x0e1c3c20 // umov w0, v1.s[3]"},
+		{"    WORD $0x4e183c20 // umov x0, v1.d[1]"},
+		{"    WORD $0x0e032c20 // smov w0, v1.b[1]"},
+		{"    WORD $0x4e062c20 // smov x0, v1.h[1]"},
+		{"    WORD $0x4e0c2c20 // smov x0, v1.s[1]"},
+		{"    WORD $0x4e22d420 // fadd v0.4s, v1.4s, v2.4s"},
+		{"    WORD $0x4e62d420 // fadd v0.2d, v1.2d, v2.2d"},
+		{"    WORD $0x0ea2d420 // fsub v0.2s, v1.2s, v2.2s"},
+		{"    WORD $0x6e22dc20 // fmul v0.4s, v1.4s, v2.4s"},
+		{"    WORD $0x6e62fc20 // fdiv v0.2d, v1.2d, v2.2d"},
+		{"    WORD $0x4e22cc20 // fmla v0.4s, v1.4s, v2.4s"},
+		{"    WORD $0x4ee2cc20 // fmls v0.2d, v1.2d, v2.2d"},
+		{"    WORD $0x4e22f420 // fmax v0.4s, v1.4s, v2.4s"},
+		{"    WORD $0x4ea2f420 // fmin v0.4s, v1.4s, v2.4s"},
+		{"    WORD $0x4e62c420 // fmaxnm v0.2d, v1.2d, v2.2d"},
+		{"    WORD $0x0ea2c420 // fminnm v0.2s, v1.2s, v2.2s"},
+		{"    WORD $0x6ea2d420 // fabd v0.4s, v1.4s, v2.4s"},
+		{"    WORD $0x6e22d420 // faddp v0.4s, v1.4s, v2.4s"},
+		{"    WORD $0x4e22e420 // fcmeq v0.4s, v1.4s, v2.4s"},
+		{"    WORD $0x6e62e420 // fcmge v0.2d, v1.2d, v2.2d"},
+		{"    WORD $0x6ea2e420 // fcmgt v0.4s, v1.4s, v2.4s"},
+		{"    WORD $0x4fa21820 // fmla v0.4s, v1.4s, v2.s[3]"},
+		{"    WORD $0x4f911020 // fmla v0.4s, v1.4s, v17.s[0]"},
+		{"    WORD $0x4fd21820 // fmla v0.2d, v1.2d, v18.d[1]"},
+		{"    WORD $0x0fa25020 // fmls v0.2s, v1.2s, v2.s[1]"},
+		{"    WORD $0x4f829820 // fmul v0.4s, v1.4s, v2.s[2]"},
+		{"    WORD $0x4e205820 // cnt v0.16b, v1.16b"},
+		{"    WORD $0x0e205820 // cnt v0.8b, v1.8b"},
+		{"    WORD $0x6e205820 // not v0.16b, v1.16b"},
+		{"    WORD $0x2e205820 // mvn v0.8b, v1.8b"},
+		{"    WORD $0x6e605820 // rbit v0.16b, v1.16b"},
+		{"    WORD $0x4ea00820 // rev64 v0.4s, v1.4s"},
+		{"    WORD $0x6e600820 // rev32 v0.8h, v1.8h"},
+		{"    WORD $0x4e201820 // rev16 v0.16b, v1.16b"},
+		{"    WORD $0x4ee0b820 // abs v0.2d, v1.2d"},
+		{"    WORD $0x6ea0b820 // neg v0.4s, v1.4s"},
+		{"    WORD $0x4e604820 // cls v0.8h, v1.8h"},
+		{"    WORD $0x6ea04820 // clz v0.4s, v1.4s"},
+		{"    WORD $0x4ea0f820 // fabs v0.4s, v1.4s"},
+		{"    WORD $0x6ee0f820 // fneg v0.2d, v1.2d"},
+		{"    WORD $0x6ea1f820 // fsqrt v0.4s, v1.4s"},
+		{"    WORD $0x4e218820 // frintn v0.4s, v1.4s"},
+		{"    WORD $0x4e619820 // frintm v0.2d, v1.2d"},
+		{"    WORD $0x0ea18820 // frintp v0.2s, v1.2s"},
+		{"    WORD $0x4ea19820 // frintz v0.4s, v1.4s"},
+		{"    WORD $0x6e218820 // frinta v0.4s, v1.4s"},
+		{"    WORD $0x6e619820 // frintx v0.2d, v1.2d"},
+		{"    WORD $0x4ea1b820 // fcvtzs v0.4s, v1.4s"},
+		{"    WORD $0x6ee1b820 // fcvtzu v0.2d, v1.2d"},
+		{"    WORD $0x4e21d820 // scvtf v0.4s, v1.4s"},
+		{"    WORD $0x6e61d820 // ucvtf v0.2d, v1.2d"},
+		{"    WORD $0x4e31b820 // addv b0, v1.16b"},
+		{"    WORD $0x4e71b820 // addv h0, v1.8h"},
+		{"    WORD $0x4eb1b820 // addv s0, v1.4s"},
+		{"    WORD $0x0e31b820 // addv b0, v1.8b"},
+		{"    WORD $0x6e30a820 // umaxv b0, v1.16b"},
+		{"    WORD $0x0e70a820 // smaxv h0, v1.4h"},
+		{"    WORD $0x6eb1a820 // uminv s0, v1.4s"},
+		{"    WORD $0x0e31a820 // sminv b0, v1.8b"},
+		{"    WORD $0x6e303820 // uaddlv h0, v1.16b"},
+		{"    WORD $0x4e703820 // saddlv s0, v1.8h"},
+		{"    WORD $0x6eb03820 // uaddlv d0, v1.4s"},
+		{"    WORD $0x6e30f820 // fmaxv s0, v1.4s"},
+		{"    WORD $0x6eb0f820 // fminv s0, v1.4s"},
+		{"    WORD $0x6e30c820 // fmaxnmv s0, v1.4s"},
+		{"    WORD $0x6eb0c820 // fminnmv s0, v1.4s"},
+		{"    WORD $0x4e023820 // zip1 v0.16b, v1.16b, v2.16b"},
+		{"    WORD $0x4e827820 // zip2 v0.4s, v1.4s, v2.4s"},
+		{"    WORD $0x4e421820 // uzp1 v0.8h, v1.8h, v2.8h"},
+		{"    WORD $0x4ec25820 // uzp2 v0.2d, v1.2d, v2.2d"},
+		{"    WORD $0x0e022820 // trn1 v0.8b, v1.8b, v2.8b"},
+		{"    WORD $0x0e826820 // trn2 v0.2s, v1.2s, v2.2s"},
+		{"    WORD $0x6e024020 // ext v0.16b, v1.16b, v2.16b, #8"},
+		{"    WORD $0x2e023820 // ext v0.8b, v1.8b, v2.8b, #7"},
+		{"    WORD $0x4e020020 // tbl v0.16b, {v1.16b}, v2.16b"},
+		{"    WORD $0x0e032020 // tbl v0.8b, {v1.16b, v2.16b}, v3.8b"},
+		{"    WORD $0x4e044020 // tbl v0.16b, {v1.16b, v2.16b, v3.16b}, v4.16b"},
+		{"    WORD $0x4e046380 // tbl v0.16b, {v28.16b-v31.16b}, v4.16b"},
+		{"    WORD $0x4e0463c0 // tbl v0.16b, {v30.16b-v1.16b}, v4.16b"},
+		{"    WORD $0x4e021020 // tbx v0.16b, {v1.16b}, v2.16b"},
+		{"    WORD $0x0e057020 // tbx v0.8b, {v1.16b-v4.16b}, v5.8b"},
+		{"    WORD $0x4e1f0420 // dup v0.16b, v1.b[15]"},
+		{"    WORD $0x4e1c0420 // dup v0.4s, v1.s[3]"},
+		{"    WORD $0x4e180420 // dup v0.2d, v1.d[1]"},
+		{"    WORD $0x0e0a0420 // dup v0.4h, v1.h[2]"},
+		{"    WORD $0x4e010c20 // dup v0.16b, w1"},
+		{"    WORD $0x4e020c20 // dup v0.8h, w1"},
+		{"    WORD $0x4e040c20 // dup v0.4s, w1"},
+		{"    WORD $0x4e080c20 // dup v0.2d, x1"},
+		{"    WORD $0x4f00e400 // movi v0.16b, #0"},
+		{"    WORD $0x0f07e7e0 // movi v0.8b, #255"},
+		{"    WORD $0x4f02e740 // movi v0.16b, #0x5a"},
+		{"    WORD $0x6f00e400 // movi v0.2d, #0"},
+		{"    WORD $0x6f05e540 // movi v0.2d, #0xff00ff00ff00ff00"},
+		{"    WORD $0x6f07e7e0 // movi v0.2d, #0xffffffffffffffff"},
+		{"    WORD $0x4f3f0420 // sshr v0.4s, v1.4s, #1"},
+		{"    WORD $0x4f400420 // sshr v0.2d, v1.2d, #64"},
+		{"    WORD $0x6f080420 // ushr v0.16b, v1.16b, #8"},
+		{"    WORD $0x6f1d0420 // ushr v0.8h, v1.8h, #3"},
+		{"    WORD $0x4f211420 // ssra v0.4s, v1.4s, #31"},
+		{"    WORD $0x6f731420 // usra v0.2d, v1.2d, #13"},
+		{"    WORD $0x4f3e2420 // srshr v0.4s, v1.4s, #2"},
+		{"    WORD $0x2f0f2420 // urshr v0.8b, v1.8b, #1"},
+		{"    WORD $0x4f205420 // shl v0.4s, v1.4s, #0"},
+		{"    WORD $0x4f7f5420 // shl v0.2d, v1.2d, #63"},
+		{"    WORD $0x4f0f5420 // shl v0.16b, v1.16b, #7"},
+		{"    WORD $0x6f255420 // sli v0.4s, v1.4s, #5"},
+		{"    WORD $0x6f794420 // sri v0.2d, v1.2d, #7"},
+		{"    WORD $0x4c407000 // ld1 {v0.16b}, [x0]"},
+		{"    WORD $0x4c40a000 // ld1 {v0.16b, v1.16b}, [x0]"},
+		{"    WORD $0x4c406000 // ld1 {v0.16b, v1.16b, v2.16b}, [x0]"},
+		{"    WORD $0x4c402000 // ld1 {v0.16b-v3.16b}, [x0]"},
+		{"    WORD $0x4cdf2800 // ld1 {v0.4s-v3.4s}, [x0], #64"},
+		{"    WORD $0x4cdfac00 // ld1 {v0.2d, v1.2d}, [x0], #32"},
+		{"    WORD $0x0cdf7000 // ld1 {v0.8b}, [x0], #8"},
+		{"    WORD $0x0cc27c00 // ld1 {v0.1d}, [x0], x2"},
+		{"    WORD $0x4c40a3ff // ld1 {v31.16b, v0.16b}, [sp]"},
+		{"    WORD $0x4c007000 // st1 {v0.16b}, [x0]"},
+		{"    WORD $0x4c9f2820 // st1 {v0.4s-v3.4s}, [x1], #64"},
+		{"    WORD $0x4c83a420 // st1 {v0.8h, v1.8h}, [x1], x3"},
+		{"    WORD $0x4c408000 // ld2 {v0.16b, v1.16b}, [x0]"},
+		{"    WORD $0x4cdf8800 // ld2 {v0.4s, v1.4s}, [x0], #32"},
+		{"    WORD $0x0c404000 // ld3 {v0.8b, v1.8b, v2.8b}, [x0]"},
+		{"    WORD $0x4cdf0800 // ld4 {v0.4s-v3.4s}, [x0], #64"},
+		{"    WORD $0x4c008c00 // st2 {v0.2d, v1.2d}, [x0]"},
+		{"    WORD $0x4c854000 // st3 {v0.16b-v2.16b}, [x0], x5"},
+		{"    WORD $0x4c000400 // st4 {v0.8h-v3.8h}, [x0]"},
+		{"    WORD $0x4d40c800 // ld1r {v0.4s}, [x0]"},
+		{"    WORD $0x4ddfc000 // ld1r {v0.16b}, [x0], #1"},
+		{"    WORD $0x4ddfcc00 // ld1r {v0.2d}, [x0], #8"},
+		{"    WORD $0x4dc1c400 // ld1r {v0.8h}, [x0], x1"},
+		{"    WORD $0x0e212820 // xtn v0.8b, v1.8h"},
+		{"    WORD $0x4e212820 // xtn2 v0.16b, v1.8h"},
+		{"    WORD $0x0e612862 // xtn v2.4h, v3.4s"},
+		{"    WORD $0x4ea12be2 // xtn2 v2.4s, v31.2d"},
+		{"    WORD $0x2e22c020 // umull v0.8h, v1.8b, v2.8b"},
+		{"    WORD $0x6e62c020 // umull2 v0.4s, v1.8h, v2.8h"},
+		{"    WORD $0x0ea2c020 // smull v0.2d, v1.2s, v2.2s"},
+		{"    WORD $0x4ea7c0c5 // smull2 v5.2d, v6.4s, v7.4s"},
+		{"    WORD $0x0e22e020 // pmull v0.8h, v1.8b, v2.8b"},
+		{"    WORD $0x0ee2e020 // pmull v0.1q, v1.1d, v2.1d"},
+		{"    WORD $0x4ee2e020 // pmull2 v0.1q, v1.2d, v2.2d"},
+		{"    WORD $0x0f0ba420 // sshll v0.8h, v1.8b, #3"},
+		{"    WORD $0x6f1fa420 // ushll2 v0.4s, v1.8h, #15"},
+		{"    WORD $0x0f20a420 // sshll v0.2d, v1.2s, #0"},
+		{"    WORD $0x2f08a420 // uxtl v0.8h, v1.8b"},
+		{"    WORD $0x4f20a420 // sxtl2 v0.2d, v1.4s"},
+		{"    WORD $0x6f10a483 // uxtl2 v3.4s, v4.8h"},
+		{"    WORD $0x4e0c1c40 // ins v0.s[1], w2"},
+		{"    WORD $0x4e181c40 // ins v0.d[1], x2"},
+		{"    WORD $0x6e1f0483 // ins v3.b[15], v4.b[0]"},
+		{"    WORD $0x4e284820 // aese v0.16b, v1.16b"},
+		{"    WORD $0x4e285820 // aesd v0.16b, v1.16b"},
+		{"    WORD $0x4e286862 // aesmc v2.16b, v3.16b"},
+		{"    WORD $0x4e287862 // aesimc v2.16b, v3.16b"},
+		// scalar floating-point
+		{"    WORD $0x1e622820 // fadd d0, d1, d2"},
+		{"    WORD $0x1e252883 // fadd s3, s4, s5"},
+		{"    WORD $0x1ee828e6 // fadd h6, h7, h8"},
+		{"    WORD $0x1e6b3949 // fsub d9, d10, d11"},
+		{"    WORD $0x1e2e09ac // fmul s12, s13, s14"},
+		{"    WORD $0x1e711a0f // fdiv d15, d16, d17"},
+		{"    WORD $0x1ef44a72 // fmax h18, h19, h20"},
+		{"    WORD $0x1e775ad5 // fmin d21, d22, d23"},
+		{"    WORD $0x1e3a6b38 // fmaxnm s24, s25, s26"},
+		{"    WORD $0x1e7d7b9b // fminnm d27, d28, d29"},
+		{"    WORD $0x1e608bfe // fnmul d30, d31, d0"},
+		{"    WORD $0x1f420c20 // fmadd d0, d1, d2, d3"},
+		{"    WORD $0x1f069ca4 // fmsub s4, s5, s6, s7"},
+		{"    WORD $0x1fea2d28 // fnmadd h8, h9, h10, h11"},
+		{"    WORD $0x1f6ebdac // fnmsub d12, d13, d14, d15"},
+		{"    WORD $0x1e60c020 // fabs d0, d1"},
+		{"    WORD $0x1e214062 // fneg s2, s3"},
+		{"    WORD $0x1ee1c0a4 // fsqrt h4, h5"},
+		{"    WORD $0x1e61c0e6 // fsqrt d6, d7"},
+		{"    WORD $0x1e644128 // frintn d8, d9"},
+		{"    WORD $0x1e24c16a // frintp s10, s11"},
+		{"    WORD $0x1ee541ac // frintm h12, h13"},
+		{"    WORD $0x1e65c1ee // frintz d14, d15"},
+		{"    WORD $0x1e264230 // frinta s16, s17"},
+		{"    WORD $0x1e674272 // frintx d18, d19"},
+		{"    WORD $0x1ee7c2b4 // frinti h20, h21"},
+		{"    WORD $0x1e604020 // fmov d0, d1"},
+		{"    WORD $0x1e204062 // fmov s2, s3"},
+		{"    WORD $0x1ee040a4 // fmov h4, h5"},
+		{"    WORD $0x1e22c020 // fcvt d0, s1"},
+		{"    WORD $0x1e624062 // fcvt s2, d3"},
+		{"    WORD $0x1e23c0a4 // fcvt h4, s5"},
+		{"    WORD $0x1ee240e6 // fcvt s6, h7"},
+		{"    WORD $0x1ee2c128 // fcvt d8, h9"},
+		{"    WORD $0x1e63c16a // fcvt h10, d11"},
+		{"    WORD $0x1e612000 // fcmp d0, d1"},
+		{"    WORD $0x1e202048 // fcmp s2, #0.0"},
+		{"    WORD $0x1ee42070 // fcmpe h3, h4"},
+		{"    WORD $0x1e6020b8 // fcmpe d5, #0.0"},
+		{"    WORD $0x1e610404 // fccmp d0, d1, #4, eq"},
+		{"    WORD $0x1e23b45f // fccmpe s2, s3, #15, lt"},
+		{"    WORD $0x1ee52480 // fccmp h4, h5, #0, hs"},
+		{"    WORD $0x1e621c20 // fcsel d0, d1, d2, ne"},
+		{"    WORD $0x1e25cc83 // fcsel s3, s4, s5, gt"},
+		{"    WORD $0x1ee8ece6 // fcsel h6, h7, h8, al"},
+		{"    WORD $0x9e670020 // fmov d0, x1"},
+		{"    WORD $0x9e660062 // fmov x2, d3"},
+		{"    WORD $0x1e2700a4 // fmov s4, w5"},
+		{"    WORD $0x1e2600e6 // fmov w6, s7"},
+		{"    WORD $0x1ee70128 // fmov h8, w9"},
+		{"    WORD $0x9ee7016a // fmov h10, x11"},
+		{"    WORD $0x1ee601ac // fmov w12, h13"},
+		{"    WORD $0x9ee601ee // fmov x14, h15"},
+		{"    WORD $0x9e6703f0 // fmov d16, xzr"},
+		{"    WORD $0x1e6e1000 // fmov d0, #1.0"},
+		{"    WORD $0x1e309001 // fmov s1, #-2.5"},
+		{"    WORD $0x1ee81002 // fmov h2, #0.125"},
+		{"    WORD $0x1e67f003 // fmov d3, #31.0"},
+		{"    WORD $0x9e6703e4 // fmov d4, #0.0"},
+		{"    WORD $0x1e2703e5 // fmov s5, #0.0"},
+		{"    WORD $0x1ee703e6 // fmov h6, #0.0"},
 	}
 
 	for i, tc := range testCases {
@@ -979,6 +1822,35 @@ func TestDWords(t *testing.T) {
 		{"    DWORD $0x04018f0604512c46 // lsr z6.h, p3/m, z2.h, #8"},
 		{"    DWORD $0x0441960704913467 // lsr z7.s, p5/m, z3.s, #16"},
 		{"    DWORD $0x04c19c0804d13c88 // lsr z8.d, p7/m, z4.d, #32"},
+		//
+		{"    DWORD $0x65cc8d2104d12c41 // fdivr z1.d, p3/m, z2.d, z9.d"},
+		{"    DWORD $0x65898d2104902c41 // fscale z1.s, p3/z, z2.s, z9.s"},
+		{"    DWORD $0x6598842004912440 // fadd z0.s, p1/m, z2.s, #1.0"},
+		{"    DWORD $0x65da842004d02400 // fmul z0.d, p1/z, z0.d, #2.0"},
+		{"    DWORD $0x659c880504902865 // fmaxnm z5.s, p2/z, z3.s, #0.0"},
+		{"    DWORD $0x4482846004912440 // srshl z0.s, p1/m, z2.s, z3.s"},
+		{"    DWORD $0x44ca846004d02400 // sqrshl z0.d, p1/z, z0.d, z3.d"},
+		{"    DWORD $0x044487a004912440 // asrd z0.s, p1/m, z2.s, #3"},
+		{"    DWORD $0x040c8b85045028a5 // srshr z5.h, p2/z, z5.h, #4"},
+		{"    DWORD $0x0406854004102440 // sqshl z0.b, p1/z, z2.b, #2"},
+		{"    DWORD $0x04223c600420bc20 // bsl z0.d, z1.d, z2.d, z3.d"},
+		{"    DWORD $0x042739050420bcc5 // eor3 z5.d, z6.d, z7.d, z8.d"},
+		{"    DWORD $0x04eb3d8a0420be8a // nbsl z10.d, z20.d, z11.d, z12.d"},
+		{"    DWORD $0x0495004004912020 // udiv z0.s, p0/m, z1.s, z2.s"},
+		{"    DWORD $0x04d70ca404d02c84 // udivr z4.d, p3/z, z4.d, z5.d"},
+		{"    DWORD $0x6588846004912440 // fabd z0.s, p1/m, z2.s, z3.s"},
+		{"    DWORD $0x65c4886204d028a2 // fmaxnm z2.d, p2/z, z5.d, z3.d"},
+		{"    DWORD $0x65458c6204512cc2 // fminnm z2.h, p3/m, z6.h, z3.h"},
+		{"    DWORD $0x658a90a404903084 // fmulx z4.s, p4/z, z4.s, z5.s"},
+		{"    DWORD $0x65c394e604d13506 // fsubr z6.d, p5/m, z8.d, z7.d"},
+		{"    DWORD $0x0482442004902400 // mla z0.s, p1/z, z1.s, z2.s"},
+		{"    DWORD $0x04c2642004d02400 // mls z0.d, p1/z, z1.d, z2.d"},
+		{"    DWORD $0x0441c84004502800 // mad z0.h, p2/z, z1.h, z2.h"},
+		{"    DWORD $0x0401e84004102800 // msb z0.b, p2/z, z1.b, z2.b"},
+		{"    DWORD $0x65a2042004902400 // fmla z0.s, p1/z, z1.s, z2.s"},
+		{"    DWORD $0x6562642004502400 // fnmls z0.h, p1/z, z1.h, z2.h"},
+		{"    DWORD $0x65e2842004d02400 // fmad z0.d, p1/z, z1.d, z2.d"},
+		{"    DWORD $0x65a2e42004902400 // fnmsb z0.s, p1/z, z1.s, z2.s"},
 	}
 
 	for i, tc := range testCases {
@@ -1057,6 +1929,418 @@ func TestZeroing(t *testing.T) {
 	}
 }
 
+// TestSME2RegisterGroups verifies that multi-vector operands must form a valid
+// register group (consecutive and aligned where required, no strided lists).
+func TestSME2RegisterGroups(t *testing.T) {
+	for _, ins := range []string{
+		"fmla za.s[w8, 0, vgx2], {z1.s, z2.s}, {z0.s, z1.s}", // misaligned group
+		"fmla za.s[w8, 0, vgx4], {z0.s-z3.s}, {z2.s-z5.s}",   // misaligned group
+		"fmla za.s[w8, 0, vgx2], {z0.s, z8.s}, z0.s",         // strided list
+		"fmla za.s[w8, 0, vgx2], {z0.s-z3.s}, z0.s",          // group size does not match vgx2
+		"fmla za.s[w8, 0, vgx2], {z0.s, z1.s}, z16.s",        // zm restricted to z0-z15
+		"fmla za.s[w12, 0, vgx2], {z0.s, z1.s}, z0.s",        // vector select restricted to w8-w11
+		"fmla za.s[w8, 8, vgx2], {z0.s, z1.s}, z0.s",         // offset out of range
+		"fmlal za.s[w8, 1:2, vgx2], {z0.h, z1.h}, z0.h",      // offset must be even
+		"movt x0, zt0[4]", // offset must be a multiple of 8
+	} {
+		if _, _, err := Assemble(ins); err == nil {
+			t.Errorf("TestSME2RegisterGroups: `%s`: expected error", ins)
+		}
+	}
+
+	cases := []struct {
+		list                 string
+		zn, count, stride    int
+		aligned, wantIsGroup bool
+	}{
+		{"{ z0.s-z3.s }", 0, 4, 1, true, true},
+		{"{ z4.s - z7.s }", 4, 4, 1, true, true},
+		{"{ z31.s, z0.s }", 31, 2, 1, false, true},
+		{"{ z31.s, z0.s }", 31, 2, 1, true, false},
+		{"{ z0.s, z8.s }", 0, 2, 8, true, true},
+		{"{ z17.h, z21.h, z25.h, z29.h }", 17, 4, 4, true, true},
+		{"{ z8.s, z16.s }", 8, 2, 8, true, false},
+	}
+	for _, tc := range cases {
+		n, zn, count, stride, _ := getZList(normalizeArgsAndBraces(strings.Fields(tc.list)))
+		if n == 0 || zn != tc.zn || count != tc.count || stride != tc.stride {
+			t.Errorf("getZList(%q): got (%d, %d, %d), want (%d, %d, %d)", tc.list, zn, count, stride, tc.zn, tc.count, tc.stride)
+		} else if got := isZGroup(zn, count, stride, tc.aligned); got != tc.wantIsGroup {
+			t.Errorf("isZGroup(%q, aligned=%v): got %v, want %v", tc.list, tc.aligned, got, tc.wantIsGroup)
+		}
+	}
+}
+
+func TestFpImmediates(t *testing.T) {
+	for _, ins := range []string{
+		"fadd z0.s, p0/m, z0.s, #2.0",  // only #0.5 and #1.0
+		"fmul z0.s, p0/m, z0.s, #1.0",  // only #0.5 and #2.0
+		"fmax z0.s, p0/m, z0.s, #-0.0", // only #0.0 and #1.0
+		"fdup z0.s, #0.1",              // not representable in 8 bits
+		"fmov z0.d, #32.0",             // out of range
+		"fmov z0.d, p0/m, #0.0625",     // out of range
+		"fcpy z0.s, p0/z, #1.0",        // merging only
+	} {
+		if _, _, err := Assemble(ins); err == nil {
+			t.Errorf("TestFpImmediates: `%s`: expected error", ins)
+		}
+	}
+
+	cases := []struct {
+		f    float64
+		imm8 int
+	}{
+		{2.0, 0x00},
+		{1.0, 0x70},
+		{0.5, 0x60},
+		{0.125, 0x40},
+		{31.0, 0x3f},
+		{-1.5, 0xf8},
+	}
+	for _, tc := range cases {
+		if ok, got := getFpImm8(tc.f); !ok || got != tc.imm8 {
+			t.Errorf("getFpImm8(%g): got (0x%02x, %v), want (0x%02x, true)", tc.f, got, ok, tc.imm8)
+		}
+	}
+}
+
+func TestFpConversionSizePairs(t *testing.T) {
+	for _, ins := range []string{
+		"fcvt z0.s, p0/m, z1.s",
+		"fcvt z0.b, p0/m, z1.h",
+		"fcvtx z0.h, p0/m, z1.s",
+		"fcvtnt z0.s, p0/m, z1.h",
+		"fcvtlt z0.h, p0/m, z1.s",
+		"fcvtxnt z0.d, p0/m, z1.s",
+		"fcvtzs z0.h, p0/m, z1.s",
+		"fcvtzu z0.h, p0/m, z1.d",
+		"scvtf z0.s, p0/m, z1.h",
+		"ucvtf z0.d, p0/m, z1.h",
+	} {
+		if _, _, err := Assemble(ins); err == nil {
+			t.Errorf("TestFpConversionSizePairs: `%s`: expected error", ins)
+		} else if !strings.Contains(err.Error(), "illegal element size pair") {
+			t.Errorf("TestFpConversionSizePairs: `%s`: got: %v", ins, err)
+		}
+	}
+}
+
+func TestScalarRegisterWidths(t *testing.T) {
+	for _, ins := range []string{
+		"umulh w0, w1, w2",
+		"smaddl w0, w1, w2, x3",
+		"smull x0, x1, x2",
+		"crc32x w0, w1, w2",
+		"crc32b w0, w1, x2",
+		"ccmp x0, w1, #4, ne",
+		"ccmp x0, #32, #2, ne",
+		"ccmp x0, x1, #16, ne",
+		"sdiv x0, w1, x2",
+		"udiv w0, w1, x2",
+		"clz w0, x1",
+		"cls x0, sp",
+	} {
+		if _, _, err := Assemble(ins); err == nil {
+			t.Errorf("TestScalarRegisterWidths: `%s`: expected error", ins)
+		}
+	}
+}
+
+// TestTruncatedOperands verifies that missing operands are reported as an error
+// rather than causing a panic.
+func TestTruncatedOperands(t *testing.T) {
+	for _, ins := range []string{
+		"sqshl z0.s",
+		"srshl z0.s",
+		"uqrshlr z0.s",
+		"udiv z0.s",
+		"udivr z0.s",
+		"sdiv z0.s",
+		"fmaxnm z0.s",
+		"fmulx z0.d, p0/m",
+		"fdiv z0.s",
+		"fdivr z0.s",
+		"fscale z0.s",
+		"frecpx z0.s",
+		"ftsmul z0.s",
+		"umulh",
+		"smulh z0.s",
+		"umulh x0",
+	} {
+		if _, _, err := Assemble(ins); err == nil {
+			t.Errorf("TestTruncatedOperands: `%s`: expected error", ins)
+		}
+	}
+}
+
+func TestNeonErrors(t *testing.T) {
+	for _, tc := range []struct {
+		ins string
+		err string
+	}{
+		{"addv s0, v1.", "unhandled instruction"},
+		{"addv s0, v1", "unhandled instruction"},
+		{"uminv b0, v1.x", "unhandled instruction"},
+		{"fmaxv s0, v1.", "unhandled instruction"},
+		{"saddlv h0, v1.16", "unhandled instruction"},
+		{"xtn v0.16b, v1.8h", "unhandled instruction"},
+		{"umull v0.4s, v1.8b, v2.8b", "unhandled instruction"},
+		{"pmull v0.4s, v1.4h, v2.4h", "unhandled instruction"},
+		{"sshll v0.8h, v1.8b, #8", "unhandled instruction"},
+		{"aese v0.8b, v1.8b", "unhandled instruction"},
+		{"sqxtn v0.8b, v1.8h", "unsupported Advanced SIMD instruction sqxtn"},
+		{"uaddl v0.8h, v1.8b, v2.8b", "unsupported Advanced SIMD instruction uaddl"},
+	} {
+		if _, _, err := Assemble(tc.ins); err == nil {
+			t.Errorf("TestNeonErrors: `%s`: expected error", tc.ins)
+		} else if !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("TestNeonErrors: `%s`: got: %v", tc.ins, err)
+		}
+	}
+}
+
+func TestFeature(t *testing.T) {
+	for _, tc := range []struct {
+		ins     string
+		feature string
+	}{
+		{"add x0, x1, x2", "base"},
+		{"ldxp x0, x1, [x2]", "base"},
+		{"ret", "base"},
+		{"add z0.s, p0/m, z0.s, z1.s", "sve"},
+		{"mul z0.s, p0/m, z0.s, z1.s", "sve"},
+		{"tbl z0.b, {z1.b}, z3.b", "sve"},
+		{"ld1d {z0.d}, p0/z, [x0]", "sve"},
+		{"add z3.s, p1/m, z1.s, z2.s", "sve"},
+		{"mul z0.s, z1.s, z2.s", "sve2"},
+		{"eor3 z0.d, z0.d, z1.d, z2.d", "sve2"},
+		{"tbl z0.b, {z1.b, z2.b}, z3.b", "sve2"},
+		{"ext z0.b, {z1.b, z2.b}, #3", "sve2"},
+		{"aese z0.b, z0.b, z1.b", "sve2"},
+		{"addp z1.s, p1/m, z1.s, z2.s", "sve2"},
+		{"mla z0.h, z1.h, z2.h[0]", "sve2"},
+		{"sdot z0.s, z1.b, z2.b", "sve"},
+		{"tblq z0.s, {z1.s}, z2.s", "sve2p1"},
+		{"revd z0.q, p0/m, z1.q", "sve2p1"},
+		{"ld1w { z8.q }, p7/z, [x9, #-8, MUL VL]", "sve2p1"},
+		{"zip1 z0.q, z1.q, z2.q", "f64mm"},
+		{"ldr zt0, [x0]", "sme2"},
+		{"zero {zt0}", "sme2"},
+		{"movt x0, zt0[0]", "sme2"},
+		{"luti2 z21.h, zt0, z10[5]", "sme2"},
+		{"fmla za.s[w8, 0, vgx2], {z0.s, z1.s}, z2.s", "sme2"},
+		{"add za.s[w8, 0, vgx2], {z0.s, z1.s}", "sme2"},
+		{"ld1w { z2.s, z3.s }, p10/z, [x4]", "sme2"},
+		{"add v0.4s, v1.4s, v2.4s", "neon"},
+		{"ld1 {v0.16b}, [x0]", "neon"},
+		{"ld1r {v0.4s}, [x0]", "neon"},
+		{"fadd d0, d1, d2", "fp"},
+		{"fmov x0, d1", "fp"},
+		{"ldr q0, [x0]", "fp"},
+		{"ldp d0, d1, [x0]", "fp"},
+		{"ldadd x0, x1, [x2]", "lse"},
+		{"cas x0, x1, [x2]", "lse"},
+		{"casp x0, x1, x2, x3, [x4]", "lse"},
+		{"crc32b w0, w1, w2", "crc"},
+		{"crc32cx w0, w1, x2", "crc"},
+	} {
+		opcode, opcode2, err := Assemble(tc.ins)
+		if err != nil {
+			t.Errorf("TestFeature: `%s`: %v", tc.ins, err)
+			continue
+		}
+		if got := Feature(tc.ins, If(opcode2 != 0, opcode2, opcode)); got != tc.feature {
+			t.Errorf("TestFeature: `%s`: got %s, want %s", tc.ins, got, tc.feature)
+		}
+	}
+}
+
+func TestFpScalarPrecision(t *testing.T) {
+	for _, ins := range []string{
+		"fadd d0, s1, d2",
+		"fmadd d0, d1, d2, h3",
+		"fabs d0, s1",
+		"fcsel d0, d1, s2, eq",
+		"fccmp s0, d1, #0, eq",
+		"fcvt d0, d1",
+		"fmov s0, x1",
+		"fmov x0, s1",
+		"fmov d0, w1",
+		"fmov d0, sp",
+		"fcmp d0, #1.0",
+		"fmov d0, #0.1",
+	} {
+		if _, _, err := Assemble(ins); err == nil {
+			t.Errorf("TestFpScalarPrecision: `%s`: expected error", ins)
+		}
+	}
+}
+
+// TestPrefixOverlap tests that a destructive operation whose second source is
+// also the destination is rejected when it would need a MOVPRFX (that would
+// overwrite the source), while the forms without overlap are still accepted
+func TestPrefixOverlap(t *testing.T) {
+	for _, ins := range []string{
+		"add z0.s, p0/m, z1.s, z0.s",
+		"sub z0.s, p0/m, z1.s, z0.s",
+		"subr z0.h, p0/m, z1.h, z0.h",
+		"mul z2.d, p1/m, z3.d, z2.d",
+		"and z0.s, p0/m, z1.s, z0.s",
+		"sdiv z0.s, p0/m, z1.s, z0.s",
+		"udivr z0.s, p0/m, z1.s, z0.s",
+		"smax z0.s, p0/z, z1.s, z0.s",
+		"fadd z0.s, p0/m, z1.s, z0.s",
+		"fdiv z0.s, p0/m, z1.s, z0.s",
+		"fdivr z0.s, p0/m, z1.s, z0.s",
+		"fmaxnm z0.d, p0/z, z1.d, z0.d",
+		"srshl z0.s, p0/m, z1.s, z0.s",
+		"urshl z0.s, p0/m, z1.s, z0.s",
+		"sqshl z0.b, p0/m, z1.b, z0.b",
+		"uqshl z0.h, p0/z, z1.h, z0.h",
+		"sqrshl z0.s, p0/m, z1.s, z0.s",
+		"uqrshlr z0.d, p0/m, z1.d, z0.d",
+		"srshlr z0.s, p0/m, z1.s, z0.s",
+		"fscale z0.s, p0/m, z1.s, z0.s",
+		"fscale z0.d, p0/z, z1.d, z0.d",
+		"fmulx z0.h, p0/m, z1.h, z0.h",
+	} {
+		if _, _, err := Assemble(ins); err == nil {
+			t.Errorf("TestPrefixOverlap: `%s`: expected error", ins)
+		}
+	}
+	for _, ins := range []string{
+		"add z0.s, p0/m, z0.s, z0.s",
+		"add z0.s, p0/z, z0.s, z0.s",
+		"add z0.s, p0/m, z1.s, z1.s",
+		"fdivr z0.s, p0/m, z1.s, z2.s",
+		"srshl z0.s, p0/m, z1.s, z2.s",
+		"srshlr z0.s, p0/m, z0.s, z1.s",
+		"fscale z0.s, p0/m, z1.s, z2.s",
+	} {
+		if _, _, err := Assemble(ins); err != nil {
+			t.Errorf("TestPrefixOverlap: `%s`: %v", ins, err)
+		}
+	}
+}
+
+// TestQRegisterErrors tests that the 128-bit q register is only accepted by the
+// SIMD&FP loads and stores
+func TestQRegisterErrors(t *testing.T) {
+	for _, ins := range []string{
+		"uaddv q0, p0, z0.s",
+		"andv q0, p0, z0.s",
+		"smaxv q0, p0, z0.s",
+		"faddv q0, p0, z0.s",
+		"fadda q0, p0, q0, z0.s",
+		"cpy z0.s, p0/m, q0",
+		"fmov q0, x0",
+		"fadd q0, q1, q2",
+		"ldp q0, d1, [x0]",
+	} {
+		if _, _, err := Assemble(ins); err == nil {
+			t.Errorf("TestQRegisterErrors: `%s`: expected error", ins)
+		}
+	}
+}
+
+func TestAtomicErrors(t *testing.T) {
+	for _, ins := range []string{
+		"ldadd",
+		"stadd",
+		"ldadd x0",
+		"ldadd x0, x1",
+		"ldadd x0, w1, [x2]",
+		"ldaddb x0, x1, [x2]",
+		"stadd x0",
+		"stadd x0, x1",
+		"swp",
+		"swpal w0",
+		"stxr w0, x0, [x1]",
+		"stxr w1, x0, [x1]",
+		"stlxrb w3, w3, [x4]",
+		"stxp w1, x0, x1, [x2]",
+		"stxp w2, x0, x1, [x2]",
+		"stlxp w0, x0, x1, [x2]",
+	} {
+		if _, _, err := Assemble(ins); err == nil {
+			t.Errorf("TestAtomicErrors: `%s`: expected error", ins)
+		}
+	}
+}
+
+func TestBarrierErrors(t *testing.T) {
+	for _, ins := range []string{
+		"isb ish",
+		"isb ld",
+		"isb #16",
+		"dmb",
+		"dmb #16",
+		"dsb foo",
+	} {
+		if _, _, err := Assemble(ins); err == nil {
+			t.Errorf("TestBarrierErrors: `%s`: expected error", ins)
+		}
+	}
+}
+
+// TestCompareReduceErrors tests that compares require a zeroing governing
+// predicate and that the FP reductions require a scalar of the element width
+func TestCompareReduceErrors(t *testing.T) {
+	for _, ins := range []string{
+		"fcmeq p0.s, p1/m, z0.s, #0.0",
+		"fcmgt p0.s, p1, z0.s, #0.0",
+		"fcmeq p0.s, p1/m, z0.s, z1.s",
+		"facge p0.d, p1, z0.d, z1.d",
+		"cmpeq p0.s, p1/m, z0.s, z1.s",
+		"cmpgt p0.b, p1, z0.b, #3",
+		"match p0.b, p1/m, z0.b, z1.b",
+		"fmaxv h0, p0, z0.s",
+		"faddv s0, p0, z0.d",
+		"fminv d0, p0, z0.h",
+		"fmaxnmv s0, p0, z0.h",
+	} {
+		if _, _, err := Assemble(ins); err == nil {
+			t.Errorf("TestCompareReduceErrors: `%s`: expected error", ins)
+		}
+	}
+	for _, ins := range []string{
+		"fcmeq p0.s, p1/z, z0.s, #0.0",
+		"fcmeq p0.s, p1/z, z0.s, z1.s",
+		"cmpgt p0.b, p1/z, z0.b, #3",
+		"faddv s0, p0, z0.s",
+		"fmaxnmv d0, p0, z0.d",
+	} {
+		if _, _, err := Assemble(ins); err != nil {
+			t.Errorf("TestCompareReduceErrors: `%s`: %v", ins, err)
+		}
+	}
+}
+
+func TestLoadStoreOffsetErrors(t *testing.T) {
+	for _, tc := range []struct {
+		ins string
+		err string
+	}{
+		{"ldr x0, [x1, #32768]", "offset must be a multiple of 8 in [0, 32760] or in [-256, 255]"},
+		{"ldrh w0, [x1, #257]", "offset must be a multiple of 2 in [0, 8190] or in [-256, 255]"},
+		{"ldr x0, [x1, #256]!", "pre/post-index offset must be in [-256, 255]"},
+		{"ldr x0, [x1, x2, lsl #2]", "register offset shift amount must be 0 or 3"},
+		{"ldr x0, #2", "literal offset must be a multiple of 4"},
+		{"str x0, #8", "literal form only defined for ldr and ldrsw"},
+		{"ldur x0, [x1, #256]", "unscaled offset must be in [-256, 255]"},
+		{"ldr x0, [x1, x2, #3]", "register offset extend must be uxtw, lsl, sxtw or sxtx"},
+		{"str b0, [x1, x2, #0]", "register offset extend must be uxtw, lsl, sxtw or sxtx"},
+		{"ldr x0, [x1, x2, asr #3]", "register offset extend must be uxtw, lsl, sxtw or sxtx"},
+		{"ldr x0, label", "labels are resolved by sve-as"},
+	} {
+		if _, _, err := Assemble(tc.ins); err == nil {
+			t.Errorf("TestLoadStoreOffsetErrors: `%s`: expected error", tc.ins)
+		} else if !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("TestLoadStoreOffsetErrors: `%s`: got: %v", tc.ins, err)
+		}
+	}
+}
+
 // TestEvalIntExpr tests the integer expression evaluator used by getImm.
 func TestEvalIntExpr(t *testing.T) {
 	cases := []struct {